@@ -0,0 +1,127 @@
+package main
+
+import (
+	"github.com/grafana/grafana-foundation-sdk/go/alerting"
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
+	"github.com/grafana/grafana-foundation-sdk/go/expr"
+	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+)
+
+const (
+	AlertFolderUid     = "nebius"
+	AlertDatasourceUid = "nebius-services"
+)
+
+var NebiusStorageAlerts = alerting.NewRuleGroupBuilder("nebius-storage").
+	FolderUid(AlertFolderUid).
+	Interval(300).
+	WithRule(alertRule("nebius-storage", "nebius-disk-days-until-full",
+		"Nebius Disk will be full in less than 7 days",
+		forecastDays(diskUsedBytes, diskSizeBytes, "24h"),
+		expr.ExprTypeThresholdConditionsEvaluatorTypeWithinRange, 0, 7,
+	).
+		NoDataState(alerting.RuleNoDataStateNoData).
+		Annotations(map[string]string{
+			"summary":     "Disk {{ $labels.disk }} will be full in {{ humanize $values.A.Value }} days.",
+			"description": "The forecast is extrapolated with predict_linear from the last 24 hours of the disk space usage.",
+		}),
+	).
+	WithRule(alertRule("nebius-storage", "nebius-filestore-days-until-full",
+		"Nebius Shared Filesystem will be full in less than 7 days",
+		forecastDays(`sum by(filestore) (`+filestoreUsedBytes+`)`, `sum by(filestore) (`+filestoreSizeBytes+`)`, "24h"),
+		expr.ExprTypeThresholdConditionsEvaluatorTypeWithinRange, 0, 7,
+	).
+		NoDataState(alerting.RuleNoDataStateNoData).
+		Annotations(map[string]string{
+			"summary":     "Shared filesystem {{ $labels.filestore }} will be full in {{ humanize $values.A.Value }} days.",
+			"description": "The forecast is extrapolated with predict_linear from the last 24 hours of the filesystem space usage.",
+		}),
+	)
+
+var NebiusSharedFilesystemAlerts = alerting.NewRuleGroupBuilder("nebius-shared-filesystem").
+	FolderUid(AlertFolderUid).
+	Interval(60).
+	WithRule(filestoreErrorRatioRule("read")).
+	WithRule(filestoreErrorRatioRule("write")).
+	WithRule(filestoreErrorRatioRule("index"))
+
+func filestoreErrorRatioRule(op string) *alerting.RuleBuilder {
+	return alertRule("nebius-shared-filesystem", "nebius-filestore-"+op+"-error-ratio",
+		"Nebius Shared Filesystem "+op+" error ratio is high",
+		filestoreErrorRatio(op, "", "5m"),
+		expr.ExprTypeThresholdConditionsEvaluatorTypeGt, filestoreErrorRatioThreshold,
+	).
+		For("5m").
+		Annotations(map[string]string{
+			"summary":     "{{ humanizePercentage $values.A.Value }} of " + op + " operations on shared filesystem {{ $labels.filestore }} fail.",
+			"description": "The error ratio is computed from filestore_" + op + "_errors and filestore_" + op + "_ops over the last 5 minutes.",
+		})
+}
+
+var NebiusObjectStorageAlerts = alerting.NewRuleGroupBuilder("nebius-object-storage").
+	FolderUid(AlertFolderUid).
+	Interval(3600).
+	WithRule(alertRule("nebius-object-storage", "nebius-bucket-abandoned-multipart",
+		"Nebius Object Storage bucket holds abandoned multipart uploads",
+		objectStorageInflightPersistentSize(`bucket!=""`),
+		expr.ExprTypeThresholdConditionsEvaluatorTypeGt, objectStorageAbandonedUploadsBytes,
+	).
+		For("1h").
+		Annotations(map[string]string{
+			"summary":     "Incomplete multipart uploads in bucket {{ $labels.bucket }} have held {{ humanize1024 $values.A.Value }}B for more than 7 days.",
+			"description": "Abandoned multipart uploads are billed as stored data. Abort them or configure a lifecycle rule to abort incomplete multipart uploads.",
+		}),
+	)
+
+var NebiusOtelCollectorAlerts = alerting.NewRuleGroupBuilder("nebius-otel-collector").
+	FolderUid(AlertFolderUid).
+	Interval(60).
+	WithRule(alertRule("nebius-otel-collector", "nebius-otelcol-dropped-logs",
+		"Kubernetes log collector drops log records",
+		otelcolDroppedLogRecords("", "5m"),
+		expr.ExprTypeThresholdConditionsEvaluatorTypeGt, 0,
+	).
+		For("10m").
+		Annotations(map[string]string{
+			"summary":     "The log collector on node {{ $labels.instance }} drops {{ humanize $values.A.Value }} log records per second.",
+			"description": "Log records are refused by the receivers or failed to be sent or enqueued by the exporters. Check the Nebius Kubernetes Log Collector dashboard.",
+		}),
+	)
+
+// alertRule fires when the result of the instant query matches the threshold,
+// or the range for range evaluators, for 15 minutes, unless For is overridden.
+// The rules are labeled with their group in alert_group.
+func alertRule(group, uid, title, query string, evaluator expr.ExprTypeThresholdConditionsEvaluatorType, params ...float64) *alerting.RuleBuilder {
+	return alerting.NewRuleBuilder(title).
+		Uid(uid).
+		FolderUID(AlertFolderUid).
+		RuleGroup(group).
+		Labels(map[string]string{"alert_group": group}).
+		WithQuery(alerting.NewQueryBuilder("A").
+			DatasourceUid(AlertDatasourceUid).
+			RelativeTimeRange(600, 0).
+			Model(prometheus.NewDataqueryBuilder().
+				Expr(query).
+				Instant().
+				RefId("A"),
+			),
+		).
+		WithQuery(alerting.NewQueryBuilder("B").
+			DatasourceUid("__expr__").
+			Model(expr.NewTypeThresholdBuilder().
+				Expression("A").
+				Conditions([]cog.Builder[expr.ExprTypeThresholdConditions]{
+					expr.NewExprTypeThresholdConditionsBuilder().
+						Evaluator(expr.NewExprTypeThresholdConditionsEvaluatorBuilder().
+							Type(evaluator).
+							Params(params),
+						),
+				}).
+				RefId("B"),
+			),
+		).
+		Condition("B").
+		For("15m").
+		NoDataState(alerting.RuleNoDataStateOK).
+		ExecErrState(alerting.RuleExecErrStateError)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+)
+
+// Annotations that dashboards opt into with Annotation. Prometheus annotations
+// mark the time ranges where the query returns a value.
+
+func prometheusAnnotation(name, query, color string) *dashboard.AnnotationQueryBuilder {
+	return dashboard.NewAnnotationQueryBuilder().
+		Name(name).
+		Datasource(DatasourceRef).
+		Expr(query).
+		IconColor(color).
+		Enable(true)
+}
+
+// lokiAnnotation marks the log lines matching the query. Dashboards using it
+// must include DatasourceLoggingVar.
+func lokiAnnotation(name, query, color string) *dashboard.AnnotationQueryBuilder {
+	return dashboard.NewAnnotationQueryBuilder().
+		Name(name).
+		Datasource(DatasourceLoggingRef).
+		Expr(query).
+		IconColor(color).
+		Enable(true)
+}
+
+// grafanaAlertsAnnotation is the built-in annotation of Grafana, which marks
+// the state changes of Grafana-managed alert rules and the annotations added
+// to the dashboard. ALERTS only has the alerts evaluated by Prometheus.
+func grafanaAlertsAnnotation() *dashboard.AnnotationQueryBuilder {
+	return dashboard.NewAnnotationQueryBuilder().
+		Name("Annotations & Alerts").
+		Datasource(dashboard.DataSourceRef{
+			Type: New("grafana"),
+			Uid:  New("-- Grafana --"),
+		}).
+		Type("dashboard").
+		BuiltIn(1).
+		IconColor("rgba(0, 211, 255, 1)").
+		Hide(true).
+		Enable(true)
+}
+
+// firingAlertsAnnotation marks the Prometheus alerts firing for the series
+// matching the selector, which may be empty.
+func firingAlertsAnnotation(selector string) *dashboard.AnnotationQueryBuilder {
+	if selector != "" {
+		selector = ", " + selector
+	}
+	return prometheusAnnotation("Firing alerts", `ALERTS{alertstate="firing"`+selector+`}`, "red")
+}
+
+// nodeRebootsAnnotation marks the boots of the instances matching the
+// selector. The window follows the step, so that no boot falls between two
+// evaluations.
+func nodeRebootsAnnotation(selector string) *dashboard.AnnotationQueryBuilder {
+	return prometheusAnnotation("Node reboots", `changes(node_boot_time_seconds{`+selector+`}[$__interval]) > 0`, "blue")
+}
+
+// kernelErrorsAnnotation marks GPU driver and OOM killer lines of the logs of
+// the instance selected by the hostname variable. Like the collapsed logs row,
+// it is opt-in, as it needs the node logs in Nebius Logging.
+func kernelErrorsAnnotation() *dashboard.AnnotationQueryBuilder {
+	return lokiAnnotation("Kernel errors", hostLogsSelector+` `+hostLogsErrors, "orange").
+		Enable(false)
+}
+
+// quotaExceededAnnotation marks the periods when Nebius Observability rejects
+// writes because of the quotas.
+func quotaExceededAnnotation() *dashboard.AnnotationQueryBuilder {
+	return prometheusAnnotation("Quota exceeded", `(sum(rate(requests_total{status_code="429"}[1m])) or vector(0)) + (sum(rate(logging_ingest_requests_total{status="quota_exceeded"}[1m])) or vector(0)) > 0`, "yellow")
+}
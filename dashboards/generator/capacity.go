@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
+	"github.com/grafana/grafana-foundation-sdk/go/common"
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/gauge"
+	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/stat"
+	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
+	"github.com/grafana/grafana-foundation-sdk/go/units"
+)
+
+var ForecastWindowVar = dashboard.NewCustomVariableBuilder("forecast_window").
+	Label("Forecast window").
+	Description("Period of history used to extrapolate the space usage.").
+	Values(dashboard.StringOrMap{
+		String: New("6h,24h,3d,7d"),
+	}).
+	Current(dashboard.VariableOption{
+		Text: dashboard.StringOrArrayOfString{
+			String: New("24h"),
+		},
+		Value: dashboard.StringOrArrayOfString{
+			String: New("24h"),
+		},
+	})
+
+// Capacity gauges of Nebius Disks and Shared Filesystems, in bytes. The I/O
+// panels do not use them, so the days-until-full alerts report NoData rather
+// than OK when they are not exported.
+const (
+	diskUsedBytes      = "disk_used_bytes"
+	diskSizeBytes      = "disk_size_bytes"
+	filestoreUsedBytes = "filestore_used_bytes"
+	filestoreSizeBytes = "filestore_size_bytes"
+)
+
+// forecastDays estimates the number of days until used reaches size from the
+// growth predicted by predict_linear over window. It is negative or infinite
+// for volumes that are not growing.
+func forecastDays(used, size, window string) string {
+	return fmt.Sprintf(`(%[2]s - %[1]s) / (predict_linear(%[1]s[%[3]s:5m], 86400) - %[1]s)`, used, size, window)
+}
+
+// daysUntilFull is forecastDays for the volumes that are growing.
+func daysUntilFull(used, size, window string) string {
+	return forecastDays(used, size, window) + ` > 0`
+}
+
+// capacityPanels returns the panels showing the space usage of a volume, where
+// used and size are the queries of the used and total bytes. The legend is
+// prepended to the series names to tell several volumes apart.
+func capacityPanels(used, size, legend string) []cog.Builder[dashboard.Panel] {
+	return []cog.Builder[dashboard.Panel]{
+		timeseries.NewPanelBuilder().
+			Title("Space usage").
+			Description("Shows used space and total capacity.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(used).
+				LegendFormat(legend + "Used").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(size).
+				LegendFormat(legend + "Capacity").
+				Range(),
+			).
+			Unit(units.BytesIEC).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(9),
+		gauge.NewPanelBuilder().
+			Title("Used space").
+			Description("Shows used space as a percentage of the capacity.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(used + ` / ` + size).
+				LegendFormat(strings.TrimSpace(legend)).
+				Instant(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			Mappings([]dashboard.ValueMapping{
+				{
+					SpecialValueMap: &dashboard.SpecialValueMap{
+						Type: dashboard.MappingTypeSpecialValue,
+						Options: dashboard.DashboardSpecialValueMapOptions{
+							Match: dashboard.SpecialValueMatchNull,
+							Result: dashboard.ValueMappingResult{
+								Text: New("N/A"),
+							},
+						},
+					},
+				},
+			}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(41, 156, 70)",
+					},
+					{
+						Value: New(0.75),
+						Color: "rgb(237, 129, 40)",
+					},
+					{
+						Value: New(0.90),
+						Color: "rgb(212, 74, 58)",
+					},
+				}),
+			).
+			Height(8).
+			Span(3),
+		stat.NewPanelBuilder().
+			Title("Days until full").
+			Description("Estimated number of days until the volume runs out of space, extrapolated with predict_linear from the forecast window. Shows N/A when the used space is not growing.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(daysUntilFull(used, size, "$forecast_window")).
+				LegendFormat(strings.TrimSpace(legend)).
+				Instant(),
+			).
+			Unit(units.Days).
+			Decimals(1).
+			Mappings([]dashboard.ValueMapping{
+				{
+					SpecialValueMap: &dashboard.SpecialValueMap{
+						Type: dashboard.MappingTypeSpecialValue,
+						Options: dashboard.DashboardSpecialValueMapOptions{
+							Match: dashboard.SpecialValueMatchNull,
+							Result: dashboard.ValueMappingResult{
+								Text: New("N/A"),
+							},
+						},
+					},
+				},
+			}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(212, 74, 58)",
+					},
+					{
+						Value: New(7.0),
+						Color: "rgb(237, 129, 40)",
+					},
+					{
+						Value: New(30.0),
+						Color: "rgb(41, 156, 70)",
+					},
+				}),
+			).
+			Height(8).
+			Span(3),
+		timeseries.NewPanelBuilder().
+			Title("Space usage forecast").
+			Description("Shows used space projected 7 days ahead with predict_linear over the forecast window, compared to the capacity.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`predict_linear(` + used + `[$forecast_window:5m], 7 * 86400)`).
+				LegendFormat(legend + "Used in 7 days").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(size).
+				LegendFormat(legend + "Capacity").
+				Range(),
+			).
+			Unit(units.BytesIEC).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(9),
+	}
+}
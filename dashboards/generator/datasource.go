@@ -21,7 +21,7 @@ var DatasourceRef = dashboard.DataSourceRef{
 	Uid:  New("${datasource}"),
 }
 
-var DatasourceLoggingVar = dashboard.NewDatasourceVariableBuilder("datasource").
+var DatasourceLoggingVar = dashboard.NewDatasourceVariableBuilder("logging_datasource").
 	Type("loki").
 	Hide(dashboard.VariableHideHideVariable).
 	Current(dashboard.VariableOption{
@@ -36,5 +36,5 @@ var DatasourceLoggingVar = dashboard.NewDatasourceVariableBuilder("datasource").
 
 var DatasourceLoggingRef = dashboard.DataSourceRef{
 	Type: New("loki"),
-	Uid:  New("${datasource}"),
+	Uid:  New("${logging_datasource}"),
 }
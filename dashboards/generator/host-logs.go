@@ -0,0 +1,111 @@
+package main
+
+import (
+	"github.com/grafana/grafana-foundation-sdk/go/common"
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/logs"
+	"github.com/grafana/grafana-foundation-sdk/go/loki"
+	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
+	"github.com/grafana/grafana-foundation-sdk/go/units"
+)
+
+// Kernel and journald logs of the instance selected by the hostname variable,
+// and the lines of GPU driver errors (XID), other NVIDIA driver messages and
+// the OOM killer among them. The otel-collector chart ships them from the
+// journal of every Kubernetes node with source set to dmesg or journald and
+// the node name in k8s_node_name.
+const (
+	hostLogsSelector = `{k8s_node_name="$nodename", source=~"dmesg|journald"}`
+
+	hostLogsXid    = `|~ "NVRM: Xid"`
+	hostLogsNvrm   = `|~ "NVRM:" !~ "NVRM: Xid"`
+	hostLogsOom    = `|~ "(?i)out of memory|oom-kill"`
+	hostLogsErrors = `|~ "(?i)NVRM:|out of memory|oom-kill"`
+)
+
+// hostLogsNodeVar resolves the instance selected by the hostname variable to
+// its host name, which is the name of its Kubernetes node.
+var hostLogsNodeVar = dashboard.NewQueryVariableBuilder("nodename").
+	Datasource(DatasourceRef).
+	Query(dashboard.StringOrMap{
+		String: New(`label_values(node_uname_info{instance_id="$hostname"}, nodename)`),
+	}).
+	Hide(dashboard.VariableHideHideVariable).
+	AllowCustomValue(false)
+
+// hostLogsRow returns a collapsed row with the system logs of the instance.
+// Dashboards using it must include DatasourceLoggingVar and hostLogsNodeVar.
+func hostLogsRow(title string) *dashboard.RowBuilder {
+	return dashboard.NewRowBuilder(title).
+		Collapsed(true).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Kernel Errors").
+			Description("Number of GPU driver errors (XID), other NVIDIA driver messages and OOM killer events in the kernel and journald logs of the instance.").
+			Datasource(DatasourceLoggingRef).
+			WithTarget(loki.NewDataqueryBuilder().
+				Expr(`sum(count_over_time(`+hostLogsSelector+` `+hostLogsXid+` [$__auto]))`).
+				LegendFormat("XID").
+				Range(true),
+			).
+			WithTarget(loki.NewDataqueryBuilder().
+				Expr(`sum(count_over_time(`+hostLogsSelector+` `+hostLogsNvrm+` [$__auto]))`).
+				LegendFormat("NVRM").
+				Range(true),
+			).
+			WithTarget(loki.NewDataqueryBuilder().
+				Expr(`sum(count_over_time(`+hostLogsSelector+` `+hostLogsOom+` [$__auto]))`).
+				LegendFormat("OOM").
+				Range(true),
+			).
+			Unit(units.Short).
+			DrawStyle(common.GraphDrawStyleBars).
+			FillOpacity(80).
+			Stacking(common.NewStackingConfigBuilder().
+				Mode(common.StackingModeNormal),
+			).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			OverrideByName("XID", []dashboard.DynamicConfigValue{fixedColor("rgb(212, 74, 58)")}).
+			OverrideByName("NVRM", []dashboard.DynamicConfigValue{fixedColor("rgb(237, 129, 40)")}).
+			OverrideByName("OOM", []dashboard.DynamicConfigValue{fixedColor("purple")}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(6).
+			Span(24),
+		).
+		WithPanel(logs.NewPanelBuilder().
+			Title("Kernel Error Messages").
+			Description("GPU driver and OOM killer lines in the kernel and journald logs of the instance.").
+			Datasource(DatasourceLoggingRef).
+			WithTarget(loki.NewDataqueryBuilder().
+				Expr(hostLogsSelector + ` ` + hostLogsErrors).
+				MaxLines(1000).
+				Range(true),
+			).
+			ShowTime(true).
+			WrapLogMessage(true).
+			EnableLogDetails(true).
+			SortOrder(common.LogsSortOrderDescending).
+			DedupStrategy(common.LogsDedupStrategyNone).
+			Height(8).
+			Span(24),
+		).
+		WithPanel(logs.NewPanelBuilder().
+			Title("System Logs").
+			Description("Kernel and journald logs of the instance.").
+			Datasource(DatasourceLoggingRef).
+			WithTarget(loki.NewDataqueryBuilder().
+				Expr(hostLogsSelector).
+				MaxLines(1000).
+				Range(true),
+			).
+			ShowTime(true).
+			WrapLogMessage(true).
+			EnableLogDetails(true).
+			SortOrder(common.LogsSortOrderDescending).
+			DedupStrategy(common.LogsDedupStrategyNone).
+			Height(12).
+			Span(24),
+		)
+}
@@ -7,22 +7,28 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/grafana/grafana-foundation-sdk/go/alerting"
 	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
 )
 
 var (
-	dir = flag.String("dir", "", "output dir")
+	dir       = flag.String("dir", "", "output dir")
+	alertsDir = flag.String("alerts-dir", "alerts", "alert rules output dir, relative to dir")
 )
 
 func main() {
 	flag.Parse()
 
 	for _, b := range []*dashboard.DashboardBuilder{
+		NebiusCompute,
 		NebiusDiskUserStats,
 		NebiusGPU,
+		NebiusInfiniBand,
+		NebiusLogs,
 		NebiusObjectStorage,
 		NebiusSharedFilesystem,
 		NebiusObservability,
+		NebiusOtelCollector,
 	} {
 		d, err := b.Build()
 		if err != nil {
@@ -43,4 +49,30 @@ func main() {
 			panic(err)
 		}
 	}
+
+	for _, b := range []*alerting.RuleGroupBuilder{
+		NebiusStorageAlerts,
+		NebiusSharedFilesystemAlerts,
+		NebiusObjectStorageAlerts,
+		NebiusOtelCollectorAlerts,
+	} {
+		g, err := b.Build()
+		if err != nil {
+			panic(err)
+		}
+
+		data, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			panic(err)
+		}
+
+		path := filepath.Join(*dir, *alertsDir,
+			fmt.Sprintf("%s.json", *g.Title),
+		)
+
+		err = os.WriteFile(path, data, 0644)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
@@ -0,0 +1,763 @@
+package main
+
+import (
+	"github.com/grafana/grafana-foundation-sdk/go/bargauge"
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
+	"github.com/grafana/grafana-foundation-sdk/go/common"
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/gauge"
+	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/stat"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
+	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
+	"github.com/grafana/grafana-foundation-sdk/go/units"
+)
+
+const (
+	hostFilesystemSelector = `instance_id="$hostname", device!="rootfs", fstype!~"tmpfs|overlay|squashfs"`
+	hostDiskSelector       = `instance_id="$hostname", device!~"loop.*|ram.*"`
+)
+
+var NebiusCompute = nebiusCompute()
+
+func nebiusCompute() *dashboard.DashboardBuilder {
+	builder := dashboard.NewDashboardBuilder("Nebius Compute").
+		Uid("nebius-compute").
+		Description("Dashboard to visualize host metrics of Nebius Compute instances collected by the node exporter.").
+		Tags([]string{"Nebius", "Compute"}).
+		Link(dashboard.NewDashboardLinkBuilder("Docs").
+			Type(dashboard.DashboardLinkTypeLink).
+			Url("https://docs.nebius.com/observability").
+			TargetBlank(true).
+			Icon("doc"),
+		).
+		Link(dashboard.NewDashboardLinkBuilder("GitHub").
+			Type(dashboard.DashboardLinkTypeLink).
+			Url("https://github.com/nebius/observability").
+			TargetBlank(true).
+			Icon("external link"),
+		).
+		WithVariable(
+			DatasourceVar,
+		).
+		WithVariable(
+			DatasourceLoggingVar,
+		).
+		WithVariable(
+			dashboard.NewQueryVariableBuilder("hostname").
+				Label("instance").
+				Datasource(DatasourceRef).
+				Query(dashboard.StringOrMap{
+					String: New("label_values(node_uname_info, instance_id)"),
+				}).
+				AllowCustomValue(false),
+		).
+		WithVariable(
+			hostLogsNodeVar,
+		).
+		WithVariable(
+			ForecastWindowVar,
+		)
+
+	for _, section := range hostSections() {
+		builder.WithRow(dashboard.NewRowBuilder(section.Title))
+		for _, panel := range section.Panels {
+			builder.WithPanel(panel)
+		}
+	}
+	builder.WithRow(hostLogsRow("Kernel and system logs"))
+
+	return builder.
+		Annotation(grafanaAlertsAnnotation()).
+		Annotation(firingAlertsAnnotation(`instance_id="$hostname"`)).
+		Annotation(nodeRebootsAnnotation(`instance_id="$hostname"`)).
+		Annotation(kernelErrorsAnnotation()).
+		Time("now-24h", "now").
+		Refresh("1m").
+		Readonly()
+}
+
+// hostRow returns a collapsed row with all panels of the Nebius Compute
+// dashboard, so that other dashboards of the instance stay in sync with it.
+// Dashboards using it must include ForecastWindowVar.
+func hostRow(title string) *dashboard.RowBuilder {
+	row := dashboard.NewRowBuilder(title).
+		Collapsed(true)
+	for _, section := range hostSections() {
+		for _, panel := range section.Panels {
+			row.WithPanel(panel)
+		}
+	}
+	return row
+}
+
+type hostSection struct {
+	Title  string
+	Panels []cog.Builder[dashboard.Panel]
+}
+
+// hostSections returns the panels of the instance selected by the hostname
+// variable. Builders are created on every call, as the dashboard builder
+// positions panels in place.
+func hostSections() []hostSection {
+	return []hostSection{
+		{
+			Title: "CPU",
+			Panels: []cog.Builder[dashboard.Panel]{
+				timeseries.NewPanelBuilder().
+					Title("CPU Usage").
+					Description("Share of CPU time spent in each mode, averaged over all CPUs of the host.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`sum by (mode) (rate(node_cpu_seconds_total{instance_id="$hostname", mode!="idle"}[$__rate_interval])) / scalar(count(node_cpu_seconds_total{instance_id="$hostname", mode="idle"}))`).
+						LegendFormat("{{mode}}").
+						Range(),
+					).
+					Unit(units.PercentUnit).
+					Min(0).
+					Max(1).
+					Stacking(common.NewStackingConfigBuilder().
+						Mode(common.StackingModeNormal),
+					).
+					FillOpacity(10).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Legend(common.NewVizLegendOptionsBuilder().
+						Calcs([]string{"lastNotNull"}).
+						ShowLegend(true),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(9),
+				timeseries.NewPanelBuilder().
+					Title("Host Load").
+					Description("Host load averages indicate system processing demand over 1, 5, and 15-minute intervals. Values reflect the number of processes waiting for resources.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_load1{instance_id="$hostname"}`).
+						LegendFormat("1min").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_load5{instance_id="$hostname"}`).
+						LegendFormat("5min").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_load15{instance_id="$hostname"}`).
+						LegendFormat("15min").
+						Range(),
+					).
+					Unit(units.Short).
+					FillOpacity(10).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Legend(common.NewVizLegendOptionsBuilder().
+						ShowLegend(true),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(6),
+				timeseries.NewPanelBuilder().
+					Title("Pressure Stall Information").
+					Description("Share of time in which some (or all, for \"full\") runnable tasks were stalled waiting for CPU, memory or I/O.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_pressure_cpu_waiting_seconds_total{instance_id="$hostname"}[$__rate_interval])`).
+						LegendFormat("CPU some").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_pressure_memory_waiting_seconds_total{instance_id="$hostname"}[$__rate_interval])`).
+						LegendFormat("Memory some").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_pressure_memory_stalled_seconds_total{instance_id="$hostname"}[$__rate_interval])`).
+						LegendFormat("Memory full").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_pressure_io_waiting_seconds_total{instance_id="$hostname"}[$__rate_interval])`).
+						LegendFormat("I/O some").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_pressure_io_stalled_seconds_total{instance_id="$hostname"}[$__rate_interval])`).
+						LegendFormat("I/O full").
+						Range(),
+					).
+					Unit(units.PercentUnit).
+					Min(0).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Legend(common.NewVizLegendOptionsBuilder().
+						Calcs([]string{"lastNotNull"}).
+						ShowLegend(true),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(9),
+			},
+		},
+		{
+			Title: "Memory",
+			Panels: []cog.Builder[dashboard.Panel]{
+				timeseries.NewPanelBuilder().
+					Title("System Memory Usage").
+					Description("Shows physical memory consumption, calculated as (Total Memory - Free Memory - Buffers - Cached).").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_memory_MemTotal_bytes{instance_id="$hostname"} - node_memory_MemFree_bytes{instance_id="$hostname"} - node_memory_Buffers_bytes{instance_id="$hostname"} - node_memory_Cached_bytes{instance_id="$hostname"}`).
+						LegendFormat("Used").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_memory_Buffers_bytes{instance_id="$hostname"}`).
+						LegendFormat("Buffered").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_memory_Cached_bytes{instance_id="$hostname"}`).
+						LegendFormat("Cached").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_memory_MemFree_bytes{instance_id="$hostname"}`).
+						LegendFormat("Free").
+						Range(),
+					).
+					Unit(units.BytesIEC).
+					Stacking(common.NewStackingConfigBuilder().
+						Mode(common.StackingModeNormal),
+					).
+					FillOpacity(10).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Legend(common.NewVizLegendOptionsBuilder().
+						Calcs([]string{"lastNotNull"}).
+						ShowLegend(true),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(9),
+				gauge.NewPanelBuilder().
+					Title("Memory Usage").
+					Description("Displays the percentage of system RAM actively in use, excluding cache and buffers.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`(node_memory_MemTotal_bytes{instance_id="$hostname"} - node_memory_MemFree_bytes{instance_id="$hostname"} - node_memory_Buffers_bytes{instance_id="$hostname"} - node_memory_Cached_bytes{instance_id="$hostname"}) / node_memory_MemTotal_bytes{instance_id="$hostname"}`).
+						Instant(),
+					).
+					Unit(units.PercentUnit).
+					Min(0).
+					Max(1).
+					Mappings([]dashboard.ValueMapping{
+						{
+							SpecialValueMap: &dashboard.SpecialValueMap{
+								Type: dashboard.MappingTypeSpecialValue,
+								Options: dashboard.DashboardSpecialValueMapOptions{
+									Match: dashboard.SpecialValueMatchNull,
+									Result: dashboard.ValueMappingResult{
+										Text: New("N/A"),
+									},
+								},
+							},
+						},
+					}).
+					Thresholds(dashboard.NewThresholdsConfigBuilder().
+						Steps([]dashboard.Threshold{
+							{
+								Color: "rgb(41, 156, 70)",
+							},
+							{
+								Value: New(0.80),
+								Color: "rgb(237, 129, 40)",
+							},
+							{
+								Value: New(0.90),
+								Color: "rgb(212, 74, 58)",
+							},
+						}),
+					).
+					Height(5).
+					Span(3),
+				timeseries.NewPanelBuilder().
+					Title("OOM Kills").
+					Description("Number of processes killed by the kernel out-of-memory killer.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`increase(node_vmstat_oom_kill{instance_id="$hostname"}[$__rate_interval])`).
+						LegendFormat("OOM kills").
+						Range(),
+					).
+					Unit(units.Short).
+					DrawStyle(common.GraphDrawStyleBars).
+					FillOpacity(80).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(12),
+			},
+		},
+		{
+			Title: "Filesystem",
+			Panels: []cog.Builder[dashboard.Panel]{
+				bargauge.NewPanelBuilder().
+					Title("Filesystem Usage by Mountpoint").
+					Description("Shows used space of each mounted filesystem as a percentage of its capacity.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`1 - node_filesystem_avail_bytes{` + hostFilesystemSelector + `} / node_filesystem_size_bytes{` + hostFilesystemSelector + `}`).
+						LegendFormat("{{mountpoint}}").
+						Instant(),
+					).
+					Unit(units.PercentUnit).
+					Min(0).
+					Max(1).
+					Orientation(common.VizOrientationHorizontal).
+					DisplayMode(common.BarGaugeDisplayModeGradient).
+					ReduceOptions(common.NewReduceDataOptionsBuilder().
+						Calcs([]string{"lastNotNull"}),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder().
+						Steps([]dashboard.Threshold{
+							{
+								Color: "rgb(41, 156, 70)",
+							},
+							{
+								Value: New(0.75),
+								Color: "rgb(237, 129, 40)",
+							},
+							{
+								Value: New(0.90),
+								Color: "rgb(212, 74, 58)",
+							},
+						}),
+					).
+					Height(6).
+					Span(8),
+				bargauge.NewPanelBuilder().
+					Title("Inode Usage by Mountpoint").
+					Description("Shows used inodes of each mounted filesystem as a percentage of the available inodes. A filesystem runs out of space when either of them is exhausted.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`1 - node_filesystem_files_free{` + hostFilesystemSelector + `} / node_filesystem_files{` + hostFilesystemSelector + `}`).
+						LegendFormat("{{mountpoint}}").
+						Instant(),
+					).
+					Unit(units.PercentUnit).
+					Min(0).
+					Max(1).
+					Orientation(common.VizOrientationHorizontal).
+					DisplayMode(common.BarGaugeDisplayModeGradient).
+					ReduceOptions(common.NewReduceDataOptionsBuilder().
+						Calcs([]string{"lastNotNull"}),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder().
+						Steps([]dashboard.Threshold{
+							{
+								Color: "rgb(41, 156, 70)",
+							},
+							{
+								Value: New(0.75),
+								Color: "rgb(237, 129, 40)",
+							},
+							{
+								Value: New(0.90),
+								Color: "rgb(212, 74, 58)",
+							},
+						}),
+					).
+					Height(6).
+					Span(8),
+				bargauge.NewPanelBuilder().
+					Title("Days Until Full").
+					Description("Estimated number of days until each filesystem runs out of space, extrapolated with predict_linear from the forecast window. Filesystems that are not filling up are not shown.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(daysUntilFull(
+							`(node_filesystem_size_bytes{`+hostFilesystemSelector+`} - node_filesystem_avail_bytes{`+hostFilesystemSelector+`})`,
+							`node_filesystem_size_bytes{`+hostFilesystemSelector+`}`,
+							"$forecast_window",
+						)).
+						LegendFormat("{{mountpoint}}").
+						Instant(),
+					).
+					Unit(units.Days).
+					Decimals(1).
+					Min(0).
+					Max(30).
+					Orientation(common.VizOrientationHorizontal).
+					DisplayMode(common.BarGaugeDisplayModeGradient).
+					ReduceOptions(common.NewReduceDataOptionsBuilder().
+						Calcs([]string{"lastNotNull"}),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder().
+						Steps([]dashboard.Threshold{
+							{
+								Color: "rgb(212, 74, 58)",
+							},
+							{
+								Value: New(7.0),
+								Color: "rgb(237, 129, 40)",
+							},
+							{
+								Value: New(14.0),
+								Color: "rgb(41, 156, 70)",
+							},
+						}),
+					).
+					Height(6).
+					Span(8),
+				timeseries.NewPanelBuilder().
+					Title("Filesystem Usage").
+					Description("Shows used space of each mounted filesystem as a percentage of its capacity.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`1 - node_filesystem_avail_bytes{` + hostFilesystemSelector + `} / node_filesystem_size_bytes{` + hostFilesystemSelector + `}`).
+						LegendFormat("{{mountpoint}}").
+						Range(),
+					).
+					Unit(units.PercentUnit).
+					Min(0).
+					Max(1).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Legend(common.NewVizLegendOptionsBuilder().
+						Calcs([]string{"lastNotNull"}).
+						ShowLegend(true),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(12),
+				timeseries.NewPanelBuilder().
+					Title("Disk Throughput").
+					Description("Measures host disk I/O activity in MB/s, tracking both read and write operations.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`irate(node_disk_written_bytes_total{` + hostDiskSelector + `}[$__interval]) or irate(node_disk_sectors_written{` + hostDiskSelector + `}[$__interval]) * 512`).
+						LegendFormat("{{device}} write").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`irate(node_disk_read_bytes_total{` + hostDiskSelector + `}[$__interval]) or irate(node_disk_sectors_read{` + hostDiskSelector + `}[$__interval]) * 512`).
+						LegendFormat("{{device}} read").
+						Range(),
+					).
+					Unit(units.BytesPerSecondSI).
+					FillOpacity(10).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(12),
+				timeseries.NewPanelBuilder().
+					Title("Disk IOPS").
+					Description("Number of read and write operations completed per second by each block device.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_disk_reads_completed_total{` + hostDiskSelector + `}[$__rate_interval])`).
+						LegendFormat("{{device}} read").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_disk_writes_completed_total{` + hostDiskSelector + `}[$__rate_interval])`).
+						LegendFormat("{{device}} write").
+						Range(),
+					).
+					Unit(units.IOOpsPerSecond).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(8),
+				timeseries.NewPanelBuilder().
+					Title("Disk Latency").
+					Description("Average time spent on a read or write operation by each block device.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_disk_read_time_seconds_total{` + hostDiskSelector + `}[$__rate_interval]) / rate(node_disk_reads_completed_total{` + hostDiskSelector + `}[$__rate_interval])`).
+						LegendFormat("{{device}} read").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_disk_write_time_seconds_total{` + hostDiskSelector + `}[$__rate_interval]) / rate(node_disk_writes_completed_total{` + hostDiskSelector + `}[$__rate_interval])`).
+						LegendFormat("{{device}} write").
+						Range(),
+					).
+					Unit(units.Seconds).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(8),
+				timeseries.NewPanelBuilder().
+					Title("Disk I/O Utilization").
+					Description("Share of time each block device was busy processing I/O requests. Values close to 100% indicate a saturated device.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_disk_io_time_seconds_total{` + hostDiskSelector + `}[$__rate_interval])`).
+						LegendFormat("{{device}}").
+						Range(),
+					).
+					Unit(units.PercentUnit).
+					Min(0).
+					Max(1).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(8),
+			},
+		},
+		{
+			Title: "Network",
+			Panels: []cog.Builder[dashboard.Panel]{
+				timeseries.NewPanelBuilder().
+					Title("Network Throughput").
+					Description("Monitors host network traffic across all network interfaces, displaying incoming (In) and outgoing (Out) data rates in kilobits per second.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`irate(node_network_receive_bytes_total{instance_id="$hostname"}[$__interval]) or irate(node_network_receive_bytes{instance_id="$hostname"}[$__interval])`).
+						LegendFormat("{{device}} In").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`irate(node_network_transmit_bytes_total{instance_id="$hostname"}[$__interval]) or irate(node_network_transmit_bytes{instance_id="$hostname"}[$__interval])`).
+						LegendFormat("{{device}} Out").
+						Range(),
+					).
+					OverrideByQuery("B", []dashboard.DynamicConfigValue{
+						{
+							Id:    "custom.transform",
+							Value: common.GraphTransformNegativeY,
+						},
+					}).
+					Unit(units.BytesPerSecondSI).
+					FillOpacity(10).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Legend(common.NewVizLegendOptionsBuilder().
+						Calcs([]string{"lastNotNull"}).
+						ShowLegend(true),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(12),
+				timeseries.NewPanelBuilder().
+					Title("Network Errors").
+					Description("Rate of packets received (In) and transmitted (Out) with errors on each network interface.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_network_receive_errs_total{instance_id="$hostname"}[$__rate_interval]) > 0`).
+						LegendFormat("{{device}} In").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_network_transmit_errs_total{instance_id="$hostname"}[$__rate_interval]) > 0`).
+						LegendFormat("{{device}} Out").
+						Range(),
+					).
+					Unit(units.PacketsPerSecond).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(6),
+				timeseries.NewPanelBuilder().
+					Title("Network Drops").
+					Description("Rate of received (In) and transmitted (Out) packets dropped on each network interface.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_network_receive_drop_total{instance_id="$hostname"}[$__rate_interval]) > 0`).
+						LegendFormat("{{device}} In").
+						Range(),
+					).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`rate(node_network_transmit_drop_total{instance_id="$hostname"}[$__rate_interval]) > 0`).
+						LegendFormat("{{device}} Out").
+						Range(),
+					).
+					Unit(units.PacketsPerSecond).
+					LineWidth(2).
+					ShowPoints(common.VisibilityModeNever).
+					Tooltip(common.NewVizTooltipOptionsBuilder().
+						Mode(common.TooltipDisplayModeMulti).
+						Sort(common.SortOrderNone),
+					).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(5).
+					Span(6),
+			},
+		},
+		{
+			Title: "System",
+			Panels: []cog.Builder[dashboard.Panel]{
+				stat.NewPanelBuilder().
+					Title("Compute instance name").
+					Description("Displays the full system identifier.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_uname_info{instance_id="$hostname"}`).
+						Format(prometheus.PromQueryFormatTable).
+						Instant(),
+					).
+					ReduceOptions(common.NewReduceDataOptionsBuilder().
+						Calcs([]string{"lastNotNull"}).
+						Fields("nodename"),
+					).
+					Mappings([]dashboard.ValueMapping{
+						{
+							SpecialValueMap: &dashboard.SpecialValueMap{
+								Type: dashboard.MappingTypeSpecialValue,
+								Options: dashboard.DashboardSpecialValueMapOptions{
+									Match: dashboard.SpecialValueMatchNull,
+									Result: dashboard.ValueMappingResult{
+										Text: New("N/A"),
+									},
+								},
+							},
+						},
+					}).
+					ColorMode(common.BigValueColorModeNone).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(2).
+					Span(3),
+				stat.NewPanelBuilder().
+					Title("Kernel").
+					Description("Displays the Linux kernel version running on the host system.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_uname_info{instance_id="$hostname"}`).
+						Format(prometheus.PromQueryFormatTable).
+						Instant(),
+					).
+					ReduceOptions(common.NewReduceDataOptionsBuilder().
+						Calcs([]string{"lastNotNull"}).
+						Fields("release"),
+					).
+					Mappings([]dashboard.ValueMapping{
+						{
+							SpecialValueMap: &dashboard.SpecialValueMap{
+								Type: dashboard.MappingTypeSpecialValue,
+								Options: dashboard.DashboardSpecialValueMapOptions{
+									Match: dashboard.SpecialValueMatchNull,
+									Result: dashboard.ValueMappingResult{
+										Text: New("N/A"),
+									},
+								},
+							},
+						},
+					}).
+					ColorMode(common.BigValueColorModeNone).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(2).
+					Span(3),
+				stat.NewPanelBuilder().
+					Title("Uptime").
+					Description("Time since the host was last booted.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`time() - node_boot_time_seconds{instance_id="$hostname"}`).
+						Instant(),
+					).
+					Unit(units.Seconds).
+					ColorMode(common.BigValueColorModeNone).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(2).
+					Span(3),
+				stat.NewPanelBuilder().
+					Title("Failed systemd units").
+					Description("Number of systemd units in the failed state.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`count(node_systemd_unit_state{instance_id="$hostname", state="failed"} == 1) or on() vector(0)`).
+						Instant(),
+					).
+					Unit(units.Short).
+					ColorMode(common.BigValueColorModeBackground).
+					Thresholds(dashboard.NewThresholdsConfigBuilder().
+						Steps([]dashboard.Threshold{
+							{
+								Color: "rgb(41, 156, 70)",
+							},
+							{
+								Value: New(1.0),
+								Color: "rgb(212, 74, 58)",
+							},
+						}),
+					).
+					Height(2).
+					Span(3),
+				table.NewPanelBuilder().
+					Title("Failed systemd units list").
+					Description("Systemd units currently in the failed state.").
+					Datasource(DatasourceRef).
+					WithTarget(prometheus.NewDataqueryBuilder().
+						Expr(`node_systemd_unit_state{instance_id="$hostname", state="failed"} == 1`).
+						Format(prometheus.PromQueryFormatTable).
+						Instant(),
+					).
+					WithTransformation(organizeFields(
+						[]string{"Time", "Value", "__name__", "instance_id", "state", "job", "instance"},
+						map[string]string{
+							"name": "Unit",
+							"type": "Type",
+						},
+					)).
+					Thresholds(dashboard.NewThresholdsConfigBuilder()).
+					Height(4).
+					Span(12),
+			},
+		},
+	}
+}
@@ -1,334 +1,772 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-foundation-sdk/go/bargauge"
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
 	"github.com/grafana/grafana-foundation-sdk/go/common"
 	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
 	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
 	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
 	"github.com/grafana/grafana-foundation-sdk/go/units"
 )
 
-var NebiusDiskUserStats = dashboard.NewDashboardBuilder("Nebius Disk").
-	Uid("nebius-disk-user-stats").
-	Description("Dashboard provides monitoring and visualization of disk performance metrics for Nebius Disks.").
-	Tags([]string{"Nebius", "Compute", "Disk"}).
-	Link(dashboard.NewDashboardLinkBuilder("Docs").
-		Type(dashboard.DashboardLinkTypeLink).
-		Url("https://docs.nebius.com/observability").
-		TargetBlank(true).
-		Icon("doc"),
-	).
-	Link(dashboard.NewDashboardLinkBuilder("GitHub").
-		Type(dashboard.DashboardLinkTypeLink).
-		Url("https://github.com/nebius/observability").
-		TargetBlank(true).
-		Icon("external link"),
-	).
-	WithVariable(
-		DatasourceVar,
-	).
-	WithVariable(
-		dashboard.NewQueryVariableBuilder("disk").
+var NebiusDiskUserStats = nebiusDiskUserStats()
+
+func nebiusDiskUserStats() *dashboard.DashboardBuilder {
+	builder := dashboard.NewDashboardBuilder("Nebius Disk").
+		Uid("nebius-disk-user-stats").
+		Description("Dashboard provides monitoring and visualization of disk performance metrics for Nebius Disks.").
+		Tags([]string{"Nebius", "Compute", "Disk"}).
+		Link(dashboard.NewDashboardLinkBuilder("Docs").
+			Type(dashboard.DashboardLinkTypeLink).
+			Url("https://docs.nebius.com/observability").
+			TargetBlank(true).
+			Icon("doc"),
+		).
+		Link(dashboard.NewDashboardLinkBuilder("GitHub").
+			Type(dashboard.DashboardLinkTypeLink).
+			Url("https://github.com/nebius/observability").
+			TargetBlank(true).
+			Icon("external link"),
+		).
+		WithVariable(
+			DatasourceVar,
+		).
+		WithVariable(
+			dashboard.NewQueryVariableBuilder("disk").
+				Datasource(DatasourceRef).
+				Query(dashboard.StringOrMap{
+					String: New("label_values(disk)"),
+				}).
+				Multi(true).
+				IncludeAll(true).
+				AllowCustomValue(false),
+		).
+		WithVariable(
+			ForecastWindowVar,
+		).
+		WithPanel(table.NewPanelBuilder().
+			Title("Disks summary").
+			Description("Latency, operations and throttling of every selected disk within the dashboard time range, sorted by saturation. Saturation is the highest ratio of read or write operations to their burst limit.").
 			Datasource(DatasourceRef).
-			Query(dashboard.StringOrMap{
-				String: New("label_values(disk)"),
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(maxByDisk(
+					`rate(disk_read_ops{disk=~"$disk"}[$__range]) / disk_read_ops_burst{disk=~"$disk"}`,
+					`rate(disk_write_ops{disk=~"$disk"}[$__range]) / disk_write_ops_burst{disk=~"$disk"}`,
+				)).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Saturation").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by (disk, le) (rate(disk_read_latency_bucket{disk=~"$disk"}[$__range])))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Read p99").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by (disk, le) (rate(disk_write_latency_bucket{disk=~"$disk"}[$__range])))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Write p99").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (disk) (rate(disk_read_ops{disk=~"$disk"}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Read IOPS").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`max by (disk) (disk_read_ops_burst{disk=~"$disk"})`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Read burst").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (disk) (rate(disk_write_ops{disk=~"$disk"}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Write IOPS").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`max by (disk) (disk_write_ops_burst{disk=~"$disk"})`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Write burst").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by (disk, le) (rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__range])))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Read throttler p99").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by (disk, le) (rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__range])))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Write throttler p99").
+				Instant(),
+			).
+			WithTransformation(dashboard.DataTransformerConfig{
+				Id:      "merge",
+				Options: map[string]any{},
 			}).
-			AllowCustomValue(false),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk read latency (quantiles)").
-		Description("Shows disk read latency quantiles in milliseconds.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.5, rate(disk_read_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p50").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.75, rate(disk_read_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p75").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.90, rate(disk_read_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p90").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.95, rate(disk_read_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p95").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.99, rate(disk_read_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p99").
-			Range(),
-		).
-		Unit(units.Milliseconds).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk write latency (quantiles)").
-		Description("Shows disk write latency quantiles in milliseconds.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.5, rate(disk_write_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p50").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.75, rate(disk_write_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p75").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.90, rate(disk_write_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p90").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.95, rate(disk_write_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p95").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.99, rate(disk_write_latency_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p99").
-			Range(),
-		).
-		Unit(units.Milliseconds).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk read throttler latency (quantiles)").
-		Description("Shows disk read throttler latency quantiles in microseconds.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.5, rate(disk_read_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p50").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.75, rate(disk_read_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p75").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.90, rate(disk_read_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p90").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.95, rate(disk_read_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p95").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.99, rate(disk_read_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p99").
-			Range(),
-		).
-		Unit(units.Microseconds).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk write throttler latency (quantiles)").
-		Description("Shows disk write throttler latency quantiles in microseconds.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.5, rate(disk_write_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p50").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.75, rate(disk_write_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p75").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.90, rate(disk_write_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p90").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.95, rate(disk_write_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p95").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.99, rate(disk_write_throttler_delay_bucket{disk="$disk"}[$__rate_interval]))`).
-			LegendFormat("p99").
-			Range(),
-		).
-		Unit(units.Microseconds).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk read operations").
-		Description("Shows disk read operations per second and burst limit.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`rate(disk_read_ops{disk="$disk"}[$__rate_interval])`).
-			LegendFormat("Read").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`disk_read_ops_burst{disk="$disk"}`).
-			LegendFormat("Read Burst").
-			Range(),
-		).
-		Unit(units.IOOpsPerSecond).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk write operations").
-		Description("Shows disk write operations per second and burst limit.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`rate(disk_write_ops{disk="$disk"}[$__rate_interval])`).
-			LegendFormat("Write").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`disk_write_ops_burst{disk="$disk"}`).
-			LegendFormat("Write Burst").
-			Range(),
-		).
-		Unit(units.IOOpsPerSecond).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk read bytes").
-		Description("Shows disk read bytes per second and burst limit.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`rate(disk_read_bytes{disk="$disk"}[$__rate_interval])`).
-			LegendFormat("Read").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`disk_read_bytes_burst{disk="$disk"}`).
-			LegendFormat("Read Burst").
-			Range(),
-		).
-		Unit(units.BytesPerSecondIEC).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk write bytes").
-		Description("Shows disk write bytes per second and burst limit.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`rate(disk_write_bytes{disk="$disk"}[$__rate_interval])`).
-			LegendFormat("Write").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`disk_write_bytes_burst{disk="$disk"}`).
-			LegendFormat("Write Burst").
-			Range(),
-		).
-		Unit(units.BytesPerSecondIEC).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk used quota").
-		Description("Shows disk quota utilization percentage.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`rate(disk_io_quota_utilization_percentage{disk="$disk"}[$__rate_interval])`).
-			LegendFormat("Quota").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`disk_io_quota_utilization_percentage_burst{disk="$disk"}`).
-			LegendFormat("Quota Burst").
-			Range(),
-		).
-		Unit(units.Percent).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
+			WithTransformation(organizeFields(
+				[]string{"Time"},
+				map[string]string{
+					"disk":                       "Disk",
+					"Value #Saturation":          "Saturation",
+					"Value #Read p99":            "Read p99",
+					"Value #Write p99":           "Write p99",
+					"Value #Read IOPS":           "Read IOPS",
+					"Value #Read burst":          "Read burst",
+					"Value #Write IOPS":          "Write IOPS",
+					"Value #Write burst":         "Write burst",
+					"Value #Read throttler p99":  "Read throttler p99",
+					"Value #Write throttler p99": "Write throttler p99",
+				},
+			)).
+			OverrideByName("Saturation", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.PercentUnit},
+				{Id: "min", Value: 0},
+				{Id: "max", Value: 1},
+				{Id: "custom.cellOptions", Value: map[string]string{
+					"type": string(common.TableCellDisplayModeGauge),
+					"mode": string(common.BarGaugeDisplayModeBasic),
+				}},
+				{Id: "thresholds", Value: dashboard.ThresholdsConfig{
+					Mode: dashboard.ThresholdsModeAbsolute,
+					Steps: []dashboard.Threshold{
+						{Color: "rgb(41, 156, 70)"},
+						{Value: New(0.75), Color: "rgb(237, 129, 40)"},
+						{Value: New(0.90), Color: "rgb(212, 74, 58)"},
+					},
+				}},
+			}).
+			OverrideByRegexp("Read p99|Write p99", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.Milliseconds},
+			}).
+			OverrideByRegexp("Read IOPS|Read burst|Write IOPS|Write burst", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.IOOpsPerSecond},
+			}).
+			OverrideByRegexp("Read throttler p99|Write throttler p99", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.Microseconds},
+			}).
+			SortBy([]cog.Builder[common.TableSortByFieldState]{
+				common.NewTableSortByFieldStateBuilder().
+					DisplayName("Saturation").
+					Desc(true),
+			}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(24),
 		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	Time("now-24h", "now").
-	Refresh("1m").
-	Readonly()
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk read latency (quantiles)").
+			Description("Shows disk read latency quantiles in milliseconds.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.5, rate(disk_read_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p50").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.75, rate(disk_read_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p75").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.90, rate(disk_read_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p90").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.95, rate(disk_read_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p95").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, rate(disk_read_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p99").
+				Range(),
+			).
+			Unit(units.Milliseconds).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk write latency (quantiles)").
+			Description("Shows disk write latency quantiles in milliseconds.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.5, rate(disk_write_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p50").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.75, rate(disk_write_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p75").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.90, rate(disk_write_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p90").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.95, rate(disk_write_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p95").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, rate(disk_write_latency_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p99").
+				Range(),
+			).
+			Unit(units.Milliseconds).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk read throttler latency (quantiles)").
+			Description("Shows disk read throttler latency quantiles in microseconds.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.5, rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p50").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.75, rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p75").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.90, rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p90").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.95, rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p95").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p99").
+				Range(),
+			).
+			Unit(units.Microseconds).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk write throttler latency (quantiles)").
+			Description("Shows disk write throttler latency quantiles in microseconds.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.5, rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p50").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.75, rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p75").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.90, rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p90").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.95, rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p95").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} p99").
+				Range(),
+			).
+			Unit(units.Microseconds).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk read operations").
+			Description("Shows disk read operations per second and burst limit.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`rate(disk_read_ops{disk=~"$disk"}[$__rate_interval])`).
+				LegendFormat("{{disk}} Read").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`disk_read_ops_burst{disk=~"$disk"}`).
+				LegendFormat("{{disk}} Read Burst").
+				Range(),
+			).
+			Unit(units.IOOpsPerSecond).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk write operations").
+			Description("Shows disk write operations per second and burst limit.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`rate(disk_write_ops{disk=~"$disk"}[$__rate_interval])`).
+				LegendFormat("{{disk}} Write").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`disk_write_ops_burst{disk=~"$disk"}`).
+				LegendFormat("{{disk}} Write Burst").
+				Range(),
+			).
+			Unit(units.IOOpsPerSecond).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk read bytes").
+			Description("Shows disk read bytes per second and burst limit.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`rate(disk_read_bytes{disk=~"$disk"}[$__rate_interval])`).
+				LegendFormat("{{disk}} Read").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`disk_read_bytes_burst{disk=~"$disk"}`).
+				LegendFormat("{{disk}} Read Burst").
+				Range(),
+			).
+			Unit(units.BytesPerSecondIEC).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk write bytes").
+			Description("Shows disk write bytes per second and burst limit.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`rate(disk_write_bytes{disk=~"$disk"}[$__rate_interval])`).
+				LegendFormat("{{disk}} Write").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`disk_write_bytes_burst{disk=~"$disk"}`).
+				LegendFormat("{{disk}} Write Burst").
+				Range(),
+			).
+			Unit(units.BytesPerSecondIEC).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Disk used quota").
+			Description("Shows disk quota utilization percentage.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`rate(disk_io_quota_utilization_percentage{disk=~"$disk"}[$__rate_interval])`).
+				LegendFormat("{{disk}} Quota").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`disk_io_quota_utilization_percentage_burst{disk=~"$disk"}`).
+				LegendFormat("{{disk}} Quota Burst").
+				Range(),
+			).
+			Unit(units.Percent).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithRow(dashboard.NewRowBuilder("Throttling analysis")).
+		WithPanel(bargauge.NewPanelBuilder().
+			Title("Time at burst limit").
+			Description("Share of the dashboard time range during which the disk operations or bandwidth were at the burst limit.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(diskTimeAtBurstLimit("disk_read_ops")).
+				LegendFormat("{{disk}} read ops").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(diskTimeAtBurstLimit("disk_write_ops")).
+				LegendFormat("{{disk}} write ops").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(diskTimeAtBurstLimit("disk_read_bytes")).
+				LegendFormat("{{disk}} read bytes").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(diskTimeAtBurstLimit("disk_write_bytes")).
+				LegendFormat("{{disk}} write bytes").
+				Instant(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			Orientation(common.VizOrientationHorizontal).
+			DisplayMode(common.BarGaugeDisplayModeGradient).
+			ReduceOptions(common.NewReduceDataOptionsBuilder().
+				Calcs([]string{"lastNotNull"}),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(41, 156, 70)",
+					},
+					{
+						Value: New(0.05),
+						Color: "rgb(237, 129, 40)",
+					},
+					{
+						Value: New(0.20),
+						Color: "rgb(212, 74, 58)",
+					},
+				}),
+			).
+			Height(9).
+			Span(8),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Throttled operations ratio").
+			Description("Share of disk operations delayed by the throttler. Operations that fall into the lowest throttler delay bucket are not counted as throttled.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`1 - min by (disk) (rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval])) / sum by (disk) (rate(disk_read_throttler_delay_count{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} read").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`1 - min by (disk) (rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval])) / sum by (disk) (rate(disk_write_throttler_delay_count{disk=~"$disk"}[$__rate_interval]))`).
+				LegendFormat("{{disk}} write").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			AxisSoftMin(0).
+			AxisSoftMax(1).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(9).
+			Span(8),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Burst utilization and throttler delay").
+			Description("Disk operations as a percentage of the burst limit, plotted against the p99 throttler delay on the right axis. Delay growing together with the utilization means the disk is throttled by its limits.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`rate(disk_read_ops{disk=~"$disk"}[$__rate_interval]) / disk_read_ops_burst{disk=~"$disk"}`).
+				LegendFormat("{{disk}} read ops").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`rate(disk_write_ops{disk=~"$disk"}[$__rate_interval]) / disk_write_ops_burst{disk=~"$disk"}`).
+				LegendFormat("{{disk}} write ops").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by (disk, le) (rate(disk_read_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval])))`).
+				LegendFormat("{{disk}} read delay p99").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by (disk, le) (rate(disk_write_throttler_delay_bucket{disk=~"$disk"}[$__rate_interval])))`).
+				LegendFormat("{{disk}} write delay p99").
+				Range(),
+			).
+			OverrideByRegexp(".* delay p99", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.Microseconds},
+				{Id: "custom.axisPlacement", Value: common.AxisPlacementRight},
+				{Id: "custom.lineStyle", Value: map[string]any{"fill": "dash", "dash": []int{10, 10}}},
+			}).
+			Unit(units.PercentUnit).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(9).
+			Span(8),
+		).
+		WithPanel(table.NewPanelBuilder().
+			Title("Sizing recommendation").
+			Description("Peak operations and bandwidth of every selected disk within the dashboard time range compared to the burst limits. Disks peaking at 90% of the limits or more need a larger size or a faster disk type, as the limits of Nebius Disks grow with both. Disks peaking below 20% of the limits may be downsized. Target IOPS and bandwidth are the limits to pick the size and disk type by, so that the peaks use 70% of them.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(diskSizingClass(maxByDisk(
+					diskPeakUtilization("disk_read_ops"),
+					diskPeakUtilization("disk_write_ops"),
+					diskPeakUtilization("disk_read_bytes"),
+					diskPeakUtilization("disk_write_bytes"),
+				))).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Recommendation").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(maxByDisk(
+					diskPeakUtilization("disk_read_ops"),
+					diskPeakUtilization("disk_write_ops"),
+				)).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Peak IOPS utilization").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(maxByDisk(
+					diskPeakUtilization("disk_read_bytes"),
+					diskPeakUtilization("disk_write_bytes"),
+				)).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Peak bandwidth utilization").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (disk) (`+diskPeakRate("disk_read_ops")+` + `+diskPeakRate("disk_write_ops")+`)`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Peak IOPS").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (disk) (`+diskPeakRate("disk_read_bytes")+` + `+diskPeakRate("disk_write_bytes")+`)`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Peak bandwidth").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(diskSizingTarget(`sum by (disk) (`+diskPeakRate("disk_read_ops")+` + `+diskPeakRate("disk_write_ops")+`)`)).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Target IOPS").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(diskSizingTarget(`sum by (disk) (`+diskPeakRate("disk_read_bytes")+` + `+diskPeakRate("disk_write_bytes")+`)`)).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Target bandwidth").
+				Instant(),
+			).
+			WithTransformation(dashboard.DataTransformerConfig{
+				Id:      "merge",
+				Options: map[string]any{},
+			}).
+			WithTransformation(organizeFields(
+				[]string{"Time"},
+				map[string]string{
+					"disk":                              "Disk",
+					"Value #Recommendation":             "Recommendation",
+					"Value #Peak IOPS utilization":      "Peak IOPS utilization",
+					"Value #Peak bandwidth utilization": "Peak bandwidth utilization",
+					"Value #Peak IOPS":                  "Peak IOPS",
+					"Value #Peak bandwidth":             "Peak bandwidth",
+					"Value #Target IOPS":                "Target IOPS",
+					"Value #Target bandwidth":           "Target bandwidth",
+				},
+			)).
+			OverrideByName("Recommendation", []dashboard.DynamicConfigValue{
+				{Id: "mappings", Value: diskSizingMappings},
+				{Id: "custom.cellOptions", Value: map[string]string{"type": string(common.TableCellDisplayModeColorText)}},
+			}).
+			OverrideByRegexp("Peak IOPS utilization|Peak bandwidth utilization", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.PercentUnit},
+				{Id: "min", Value: 0},
+				{Id: "max", Value: 1},
+				{Id: "custom.cellOptions", Value: map[string]string{
+					"type": string(common.TableCellDisplayModeGauge),
+					"mode": string(common.BarGaugeDisplayModeBasic),
+				}},
+				{Id: "thresholds", Value: dashboard.ThresholdsConfig{
+					Mode: dashboard.ThresholdsModeAbsolute,
+					Steps: []dashboard.Threshold{
+						{Color: "rgb(41, 156, 70)"},
+						{Value: New(0.75), Color: "rgb(237, 129, 40)"},
+						{Value: New(0.90), Color: "rgb(212, 74, 58)"},
+					},
+				}},
+			}).
+			OverrideByRegexp("^(Peak|Target) IOPS$", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.IOOpsPerSecond},
+			}).
+			OverrideByRegexp("^(Peak|Target) bandwidth$", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.BytesPerSecondIEC},
+			}).
+			SortBy([]cog.Builder[common.TableSortByFieldState]{
+				common.NewTableSortByFieldStateBuilder().
+					DisplayName("Peak IOPS utilization").
+					Desc(true),
+			}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(24),
+		)
+
+	builder.WithRow(dashboard.NewRowBuilder("Capacity"))
+	for _, panel := range capacityPanels(
+		diskUsedBytes+`{disk=~"$disk"}`,
+		diskSizeBytes+`{disk=~"$disk"}`,
+		"{{disk}} ",
+	) {
+		builder.WithPanel(panel)
+	}
+
+	return builder.
+		Annotation(grafanaAlertsAnnotation()).
+		Annotation(firingAlertsAnnotation(`disk=~"$disk"`)).
+		Time("now-24h", "now").
+		Refresh("1m").
+		Readonly()
+}
+
+// maxByDisk returns the highest value of the queries for each disk. The
+// queries are told apart with a label, as "or" drops series whose labels
+// match the left-hand side.
+func maxByDisk(queries ...string) string {
+	parts := make([]string, len(queries))
+	for i, query := range queries {
+		parts[i] = fmt.Sprintf(`label_replace(%s, "query", "%d", "", "")`, query, i)
+	}
+	return `max by (disk) (` + strings.Join(parts, ` or `) + `)`
+}
+
+// diskPeakRate returns the highest per second rate of the counter within the
+// dashboard time range.
+func diskPeakRate(metric string) string {
+	return fmt.Sprintf(`max_over_time(rate(%s{disk=~"$disk"}[5m])[$__range:1m])`, metric)
+}
+
+// diskPeakUtilization returns the highest rate of the counter within the
+// dashboard time range relative to its burst limit.
+func diskPeakUtilization(metric string) string {
+	return fmt.Sprintf(`%s / max_over_time(%s_burst{disk=~"$disk"}[$__range])`, diskPeakRate(metric), metric)
+}
+
+// diskTimeAtBurstLimit returns the share of the dashboard time range during
+// which the rate of the counter was within 5% of its burst limit.
+func diskTimeAtBurstLimit(metric string) string {
+	return fmt.Sprintf(`avg_over_time((rate(%[1]s{disk=~"$disk"}[5m]) >= bool 0.95 * %[1]s_burst{disk=~"$disk"})[$__range:1m])`, metric)
+}
+
+// diskSizingClass sorts disks by their peak utilization into 0 when it is
+// below 20%, 2 when it is 90% or more and 1 in between.
+func diskSizingClass(utilization string) string {
+	return fmt.Sprintf(`(%[1]s >= bool 0.9) + (%[1]s >= bool 0.2)`, utilization)
+}
+
+// diskSizingTarget returns the limit at which the peak rate uses 70% of it.
+func diskSizingTarget(peak string) string {
+	return peak + ` / 0.7`
+}
+
+var diskSizingMappings = []dashboard.ValueMapping{
+	{
+		ValueMap: &dashboard.ValueMap{
+			Type: dashboard.MappingTypeValueToText,
+			Options: map[string]dashboard.ValueMappingResult{
+				"0": {
+					Text:  New("May be downsized"),
+					Color: New("rgb(237, 129, 40)"),
+				},
+				"1": {
+					Text:  New("Sized right"),
+					Color: New("rgb(41, 156, 70)"),
+				},
+				"2": {
+					Text:  New("Increase the size or use a faster disk type"),
+					Color: New("rgb(212, 74, 58)"),
+				},
+			},
+		},
+	},
+}
@@ -1,11 +1,17 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-foundation-sdk/go/bargauge"
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
 	"github.com/grafana/grafana-foundation-sdk/go/common"
 	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
 	"github.com/grafana/grafana-foundation-sdk/go/gauge"
 	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
 	"github.com/grafana/grafana-foundation-sdk/go/stat"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
 	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
 	"github.com/grafana/grafana-foundation-sdk/go/units"
 )
@@ -26,9 +32,17 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		TargetBlank(true).
 		Icon("external link"),
 	).
+	Link(dashboard.NewDashboardLinkBuilder("Host").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("/d/nebius-compute?var-hostname=${hostname}&${__url_time_range}").
+		Icon("dashboard"),
+	).
 	WithVariable(
 		DatasourceVar,
 	).
+	WithVariable(
+		DatasourceLoggingVar,
+	).
 	WithVariable(
 		dashboard.NewQueryVariableBuilder("hostname").
 			Label("instance").
@@ -38,46 +52,86 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 			}).
 			AllowCustomValue(false),
 	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Host Load").
-		Description("Host load averages indicate system processing demand over 1, 5, and 15-minute intervals. Values reflect the number of processes waiting for resources.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_load1{instance_id="$hostname"}`).
-			LegendFormat("1min").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_load5{instance_id="$hostname"}`).
-			LegendFormat("5min").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_load15{instance_id="$hostname"}`).
-			LegendFormat("15min").
-			Range(),
-		).
-		Unit(units.Short).
-		FillOpacity(10).
-		LineWidth(2).
-		ShowPoints(common.VisibilityModeNever).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()).
-		Height(5).
-		Span(6),
+	WithVariable(
+		hostLogsNodeVar,
+	).
+	WithVariable(
+		ForecastWindowVar,
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("mig").
+			Label("MIG instance").
+			Description("Multi-Instance GPU slices reported by DCGM on the selected instance, as parent GPU UUID / GPU instance ID. Empty on nodes without MIG.").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New(`query_result(` + migInstances + `)`),
+			}).
+			Regex(`/mig="([^"]+)"/`).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".+").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("namespace").
+			Description("Kubernetes namespaces of the pods that have GPUs of the selected instance allocated.").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New(`label_values(DCGM_FI_DEV_GPU_UTIL{instance_id="$hostname", pod!=""}, namespace)`),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".*").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("pod").
+			Description("Kubernetes pods that have GPUs of the selected instance allocated.").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New(`label_values(DCGM_FI_DEV_GPU_UTIL{instance_id="$hostname", namespace=~"$namespace", pod!=""}, pod)`),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".+").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("slurm_job").
+			Label("Slurm job").
+			Description("Slurm job to show GPUs of, across all its nodes. Select All to show the GPUs of the selected instance instead.").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New("label_values(slurm_job_gpu_info, job_id)"),
+			}).
+			Current(dashboard.VariableOption{
+				Text: dashboard.StringOrArrayOfString{
+					String: New("All"),
+				},
+				Value: dashboard.StringOrArrayOfString{
+					String: New("$__all"),
+				},
+			}).
+			IncludeAll(true).
+			AllValue("").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		priceVariable("price_per_kwh", "Price per kWh",
+			"Electricity price in USD used to estimate the energy cost.",
+			gpuPrices.KWh),
+	).
+	WithVariable(
+		priceVariable("price_per_gpu_hour", "Price per GPU-hour",
+			"GPU price in USD per hour used to estimate the compute cost.",
+			gpuPrices.GPUHour),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("GPU Power Usage").
 		Description("Tracks real-time power consumption of each GPU in watts.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`DCGM_FI_DEV_POWER_USAGE{instance_id="$hostname"}`).
+			Expr(gpuQuery(`DCGM_FI_DEV_POWER_USAGE{%s}`)).
 			LegendFormat("{{uuid}}").
 			Range(),
 		).
@@ -94,19 +148,26 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(8),
+		Span(10),
 	).
 	WithPanel(gauge.NewPanelBuilder().
 		Title("GPU Total Power").
-		Description("Displays the combined power consumption of all GPUs in the system, measured in watts.").
+		Description("Displays the combined power consumption of all GPUs in the system, measured in watts. The gauge range is the combined power limit of the GPUs.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(DCGM_FI_DEV_POWER_USAGE{instance_id="$hostname"})`).
+			Expr(`sum(`+gpuQuery(`DCGM_FI_DEV_POWER_USAGE{%s}`)+`)`).
+			RefId("A").
 			Instant(),
 		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+gpuQuery(gpuPowerLimit)+`)`).
+			LegendFormat("Limit").
+			RefId("Limit").
+			Instant(),
+		).
+		WithTransformation(maxFromQuery("Limit")).
 		Unit(units.Watt).
 		Min(0).
-		Max(2400).
 		Mappings([]dashboard.ValueMapping{
 			{
 				SpecialValueMap: &dashboard.SpecialValueMap{
@@ -121,29 +182,30 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 			},
 		}).
 		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Mode(dashboard.ThresholdsModePercentage).
 			Steps([]dashboard.Threshold{
 				{
 					Color: "rgb(41, 156, 70)",
 				},
 				{
-					Value: New(1800.0),
+					Value: New(75.0),
 					Color: "rgb(237, 129, 40)",
 				},
 				{
-					Value: New(2200.0),
+					Value: New(92.0),
 					Color: "rgb(212, 74, 58)",
 				},
 			}),
 		).
 		Height(5).
-		Span(3),
+		Span(4),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("NVLINK Bandwidth").
 		Description("Measures data transfer rates between GPUs over NVLINK interconnects in bytes per second.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(DCGM_FI_DEV_NVLINK_BANDWIDTH_TOTAL{instance_id="$hostname"}[$__rate_interval]))`).
+			Expr(`sum(`+gpuQuery(`rate(DCGM_FI_DEV_NVLINK_BANDWIDTH_TOTAL{%s}[$__rate_interval])`)+`)`).
 			LegendFormat("Total").
 			Range(),
 		).
@@ -160,57 +222,14 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(7),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("System Memory Usage").
-		Description("Shows physical memory consumption, calculated as (Total Memory - Free Memory - Buffers - Cached).").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_memory_MemTotal_bytes{instance_id="$hostname"} - node_memory_MemFree_bytes{instance_id="$hostname"} - node_memory_Buffers_bytes{instance_id="$hostname"} - node_memory_Cached_bytes{instance_id="$hostname"}`).
-			LegendFormat("Used").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_memory_Buffers_bytes{instance_id="$hostname"}`).
-			LegendFormat("Buffered").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_memory_Cached_bytes{instance_id="$hostname"}`).
-			LegendFormat("Cached").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_memory_MemFree_bytes{instance_id="$hostname"}`).
-			LegendFormat("Free").
-			Range(),
-		).
-		Unit(units.BytesIEC).
-		Stacking(common.NewStackingConfigBuilder().
-			Mode(common.StackingModeNormal),
-		).
-		FillOpacity(10).
-		LineWidth(2).
-		ShowPoints(common.VisibilityModeNever).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			Calcs([]string{"lastNotNull"}).
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()).
-		Height(5).
-		Span(6),
+		Span(10),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("GPU Temperature").
 		Description("Monitors the core temperature of each GPU in degrees Celsius.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`DCGM_FI_DEV_GPU_TEMP{instance_id="$hostname"}`).
+			Expr(gpuQuery(`DCGM_FI_DEV_GPU_TEMP{%s}`)).
 			LegendFormat("{{uuid}}").
 			Range(),
 		).
@@ -223,19 +242,26 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(8),
+		Span(10),
 	).
 	WithPanel(gauge.NewPanelBuilder().
 		Title("GPU Avg. Temperature").
-		Description("Displays the average temperature across all GPUs in the system.").
+		Description("Displays the average temperature across all GPUs in the system. The gauge range is the maximum operating temperature of the GPUs.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`avg(DCGM_FI_DEV_GPU_TEMP{instance_id="$hostname"})`).
+			Expr(`avg(`+gpuQuery(`DCGM_FI_DEV_GPU_TEMP{%s}`)+`)`).
+			RefId("A").
 			Instant(),
 		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`min(`+gpuQuery(gpuMaxOperatingTemp)+`)`).
+			LegendFormat("Limit").
+			RefId("Limit").
+			Instant(),
+		).
+		WithTransformation(maxFromQuery("Limit")).
 		Unit(units.Celsius).
 		Min(0).
-		Max(90).
 		Mappings([]dashboard.ValueMapping{
 			{
 				SpecialValueMap: &dashboard.SpecialValueMap{
@@ -250,34 +276,35 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 			},
 		}).
 		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Mode(dashboard.ThresholdsModePercentage).
 			Steps([]dashboard.Threshold{
 				{
 					Color: "rgb(41, 156, 70)",
 				},
 				{
-					Value: New(83.0),
+					Value: New(95.0),
 					Color: "rgb(237, 129, 40)",
 				},
 				{
-					Value: New(87.0),
+					Value: New(100.0),
 					Color: "rgb(212, 74, 58)",
 				},
 			}),
 		).
 		Height(5).
-		Span(3),
+		Span(4),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("PCIe Throughput").
 		Description("Tracks data transfer rates between GPUs and the host system over PCIe connections in MB/s, showing both transmit (Tx) and receive (Rx) traffic.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(DCGM_FI_PROF_PCIE_TX_BYTES{instance_id="$hostname"}[$__interval])`).
+			Expr(gpuQuery(`irate(DCGM_FI_PROF_PCIE_TX_BYTES{%s}[$__interval])`)).
 			LegendFormat("{{uuid}} Tx").
 			Range(),
 		).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(DCGM_FI_PROF_PCIE_RX_BYTES{instance_id="$hostname"}[$__interval])`).
+			Expr(gpuQuery(`irate(DCGM_FI_PROF_PCIE_RX_BYTES{%s}[$__interval])`)).
 			LegendFormat("{{uuid}} Rx").
 			Range(),
 		).
@@ -300,91 +327,7 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(7),
-	).
-	WithPanel(gauge.NewPanelBuilder().
-		Title("Disk Usage").
-		Description("Shows total disk consumption as a percentage of total capacity.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`avg((node_filesystem_size_bytes{instance_id="$hostname", device!="rootfs"} - node_filesystem_avail_bytes{instance_id="$hostname", device!="rootfs"}) / node_filesystem_size_bytes{instance_id="$hostname", device!="rootfs"})`).
-			Instant(),
-		).
-		Unit(units.PercentUnit).
-		Min(0).
-		Max(1).
-		Mappings([]dashboard.ValueMapping{
-			{
-				SpecialValueMap: &dashboard.SpecialValueMap{
-					Type: dashboard.MappingTypeSpecialValue,
-					Options: dashboard.DashboardSpecialValueMapOptions{
-						Match: dashboard.SpecialValueMatchNull,
-						Result: dashboard.ValueMappingResult{
-							Text: New("N/A"),
-						},
-					},
-				},
-			},
-		}).
-		Thresholds(dashboard.NewThresholdsConfigBuilder().
-			Steps([]dashboard.Threshold{
-				{
-					Color: "rgb(41, 156, 70)",
-				},
-				{
-					Value: New(0.75),
-					Color: "rgb(237, 129, 40)",
-				},
-				{
-					Value: New(0.90),
-					Color: "rgb(212, 74, 58)",
-				},
-			}),
-		).
-		Height(5).
-		Span(3),
-	).
-	WithPanel(gauge.NewPanelBuilder().
-		Title("Memory Usage").
-		Description("Displays the percentage of system RAM actively in use, excluding cache and buffers.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`(node_memory_MemTotal_bytes{instance_id="$hostname"} - node_memory_MemFree_bytes{instance_id="$hostname"} - node_memory_Buffers_bytes{instance_id="$hostname"} - node_memory_Cached_bytes{instance_id="$hostname"}) / node_memory_MemTotal_bytes{instance_id="$hostname"}`).
-			Instant(),
-		).
-		Unit(units.PercentUnit).
-		Min(0).
-		Max(1).
-		Mappings([]dashboard.ValueMapping{
-			{
-				SpecialValueMap: &dashboard.SpecialValueMap{
-					Type: dashboard.MappingTypeSpecialValue,
-					Options: dashboard.DashboardSpecialValueMapOptions{
-						Match: dashboard.SpecialValueMatchNull,
-						Result: dashboard.ValueMappingResult{
-							Text: New("N/A"),
-						},
-					},
-				},
-			},
-		}).
-		Thresholds(dashboard.NewThresholdsConfigBuilder().
-			Steps([]dashboard.Threshold{
-				{
-					Color: "rgb(41, 156, 70)",
-				},
-				{
-					Value: New(0.80),
-					Color: "rgb(237, 129, 40)",
-				},
-				{
-					Value: New(0.90),
-					Color: "rgb(212, 74, 58)",
-				},
-			}),
-		).
-		Height(5).
-		Span(3),
+		Span(10),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("GPU Utilization").
@@ -392,7 +335,7 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		Unit(units.Percent).
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`DCGM_FI_DEV_GPU_UTIL{instance_id="$hostname"}`).
+			Expr(gpuQuery(`DCGM_FI_DEV_GPU_UTIL{%s}`)).
 			LegendFormat("{{uuid}}").
 			Range(),
 		).
@@ -404,14 +347,14 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(8),
+		Span(20),
 	).
 	WithPanel(gauge.NewPanelBuilder().
 		Title("GPU Total Utilization").
 		Description("Displays the utilization across all GPUs in the system.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`avg(DCGM_FI_DEV_GPU_UTIL{instance_id="$hostname"})`).
+			Expr(`avg(`+gpuQuery(`DCGM_FI_DEV_GPU_UTIL{%s}`)+`)`).
 			Instant(),
 		).
 		Unit(units.Percent).
@@ -446,76 +389,14 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 			}),
 		).
 		Height(5).
-		Span(3),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Network Throughput").
-		Description("Monitors host network traffic across all network interfaces, displaying incoming (In) and outgoing (Out) data rates in kilobits per second.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(node_network_receive_bytes_total{instance_id="$hostname"}[$__interval]) or irate(node_network_receive_bytes{instance_id="$hostname"}[$__interval])`).
-			LegendFormat("{{device}} In").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(node_network_transmit_bytes_total{instance_id="$hostname"}[$__interval]) or irate(node_network_transmit_bytes{instance_id="$hostname"}[$__interval])`).
-			LegendFormat("{{device}} Out").
-			Range(),
-		).
-		OverrideByQuery("B", []dashboard.DynamicConfigValue{
-			{
-				Id:    "custom.transform",
-				Value: common.GraphTransformNegativeY,
-			},
-		}).
-		Unit(units.BytesPerSecondSI).
-		FillOpacity(10).
-		LineWidth(2).
-		ShowPoints(common.VisibilityModeNever).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			Calcs([]string{"lastNotNull"}).
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()).
-		Height(5).
-		Span(7),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("Disk Throughput").
-		Description("Measures host disk I/O activity in MB/s, tracking both read and write operations.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(node_disk_written_bytes_total{instance_id="$hostname"}[$__interval]) or irate(node_disk_sectors_written{instance_id="$hostname"}[$__interval]) * 512`).
-			LegendFormat("{{device}} write").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(node_disk_read_bytes_total{instance_id="$hostname"}[$__interval]) or irate(node_disk_sectors_read{instance_id="$hostname"}[$__interval]) * 512`).
-			LegendFormat("{{device}} read").
-			Range(),
-		).
-		Unit(units.BytesPerSecondSI).
-		FillOpacity(10).
-		LineWidth(2).
-		ShowPoints(common.VisibilityModeNever).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()).
-		Height(5).
-		Span(6),
+		Span(4),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("GPU Memory Copy Utilization").
 		Description("Measures the percentage of time the GPU's copy engines are actively transferring data between host and device memory.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`DCGM_FI_DEV_MEM_COPY_UTIL{instance_id="$hostname"}`).
+			Expr(gpuQuery(`DCGM_FI_DEV_MEM_COPY_UTIL{%s}`)).
 			LegendFormat("{{uuid}}").
 			Range(),
 		).
@@ -528,14 +409,14 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(8),
+		Span(10),
 	).
 	WithPanel(gauge.NewPanelBuilder().
 		Title("GPU Total Memory Copy Utilization").
 		Description("Displays the average utilization of GPU memory copy engines across all GPUs, showing the percentage of time spent transferring data between host and device memory.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`avg(DCGM_FI_DEV_MEM_COPY_UTIL{instance_id="$hostname"})`).
+			Expr(`avg(`+gpuQuery(`DCGM_FI_DEV_MEM_COPY_UTIL{%s}`)+`)`).
 			Instant(),
 		).
 		Unit(units.Percent).
@@ -570,19 +451,24 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 			}),
 		).
 		Height(5).
-		Span(3),
+		Span(4),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("InfiniBand Throughput").
 		Description("Tracks data transfer rates over InfiniBand network interfaces, a high-performance, low-latency interconnect commonly used in HPC and GPU clusters.").
 		Datasource(DatasourceRef).
+		Links([]cog.Builder[dashboard.DashboardLink]{
+			dashboard.NewDashboardLinkBuilder("InfiniBand ports").
+				Type(dashboard.DashboardLinkTypeLink).
+				Url("/d/nebius-infiniband?var-hostname=${hostname}&${__url_time_range}"),
+		}).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(node_infiniband_port_data_transmitted_bytes_total{instance_id="$hostname", device!~"hfi.+"}[$__interval]) or irate(node_infiniband_port_data_transmitted_bytes_total{instance_id="$hostname", device=~"hfi.+"}[$__interval]) * 2`).
+			Expr(infinibandBytes("transmitted", `instance_id="$hostname"`)).
 			LegendFormat("{{device}} Out").
 			Range(),
 		).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`irate(node_infiniband_port_data_received_bytes_total{instance_id="$hostname", device!~"hfi.+"}[$__interval]) or irate(node_infiniband_port_data_received_bytes_total{instance_id="$hostname", device=~"hfi.+"}[$__interval]) * 2`).
+			Expr(infinibandBytes("received", `instance_id="$hostname"`)).
 			LegendFormat("{{device}} In").
 			Range(),
 		).
@@ -605,76 +491,14 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(7),
-	).
-	WithPanel(stat.NewPanelBuilder().
-		Title("Compute instance name").
-		Description("Displays the full system identifier.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_uname_info{instance_id="$hostname"}`).
-			Format(prometheus.PromQueryFormatTable).
-			Instant(),
-		).
-		ReduceOptions(common.NewReduceDataOptionsBuilder().
-			Calcs([]string{"lastNotNull"}).
-			Fields("nodename"),
-		).
-		Mappings([]dashboard.ValueMapping{
-			{
-				SpecialValueMap: &dashboard.SpecialValueMap{
-					Type: dashboard.MappingTypeSpecialValue,
-					Options: dashboard.DashboardSpecialValueMapOptions{
-						Match: dashboard.SpecialValueMatchNull,
-						Result: dashboard.ValueMappingResult{
-							Text: New("N/A"),
-						},
-					},
-				},
-			},
-		}).
-		ColorMode(common.BigValueColorModeNone).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()).
-		Height(2).
-		Span(3),
-	).
-	WithPanel(stat.NewPanelBuilder().
-		Title("Kernel").
-		Description("Displays the Linux kernel version running on the host system.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`node_uname_info{instance_id="$hostname"}`).
-			Format(prometheus.PromQueryFormatTable).
-			Instant(),
-		).
-		ReduceOptions(common.NewReduceDataOptionsBuilder().
-			Calcs([]string{"lastNotNull"}).
-			Fields("release"),
-		).
-		Mappings([]dashboard.ValueMapping{
-			{
-				SpecialValueMap: &dashboard.SpecialValueMap{
-					Type: dashboard.MappingTypeSpecialValue,
-					Options: dashboard.DashboardSpecialValueMapOptions{
-						Match: dashboard.SpecialValueMatchNull,
-						Result: dashboard.ValueMappingResult{
-							Text: New("N/A"),
-						},
-					},
-				},
-			},
-		}).
-		ColorMode(common.BigValueColorModeNone).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()).
-		Height(2).
-		Span(3),
+		Span(10),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("GPU Memory Usage").
 		Description("Displays the percentage of GPU memory currently allocated, calculated as used memory divided by total available memory.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`DCGM_FI_DEV_FB_USED{instance_id="$hostname"} / (DCGM_FI_DEV_FB_USED{instance_id="$hostname"} + DCGM_FI_DEV_FB_FREE{instance_id="$hostname"})`).
+			Expr(gpuQuery(`DCGM_FI_DEV_FB_USED{%[1]s} / (DCGM_FI_DEV_FB_USED{%[1]s} + DCGM_FI_DEV_FB_FREE{%[1]s})`)).
 			LegendFormat("{{uuid}}").
 			Range(),
 		).
@@ -687,19 +511,26 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(8),
+		Span(10),
 	).
 	WithPanel(gauge.NewPanelBuilder().
 		Title("GPU Power Draw").
-		Description("Displays the average power consumption of GPUs in watts.").
+		Description("Displays the average power consumption of GPUs in watts. The gauge range is the power limit of the GPUs.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`avg(DCGM_FI_DEV_POWER_USAGE{instance_id="$hostname"})`).
+			Expr(`avg(`+gpuQuery(`DCGM_FI_DEV_POWER_USAGE{%s}`)+`)`).
+			RefId("A").
 			Instant(),
 		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`avg(`+gpuQuery(gpuPowerLimit)+`)`).
+			LegendFormat("Limit").
+			RefId("Limit").
+			Instant(),
+		).
+		WithTransformation(maxFromQuery("Limit")).
 		Unit(units.Watt).
 		Min(0).
-		Max(3200).
 		Mappings([]dashboard.ValueMapping{
 			{
 				SpecialValueMap: &dashboard.SpecialValueMap{
@@ -714,29 +545,30 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 			},
 		}).
 		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Mode(dashboard.ThresholdsModePercentage).
 			Steps([]dashboard.Threshold{
 				{
 					Color: "rgb(41, 156, 70)",
 				},
 				{
-					Value: New(2600.0),
+					Value: New(80.0),
 					Color: "rgb(237, 129, 40)",
 				},
 				{
-					Value: New(3000.0),
+					Value: New(92.0),
 					Color: "rgb(212, 74, 58)",
 				},
 			}),
 		).
 		Height(5).
-		Span(3),
+		Span(4),
 	).
 	WithPanel(timeseries.NewPanelBuilder().
 		Title("GPU Power Draw").
 		Description("Tracks total GPU power consumption over time.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`DCGM_FI_DEV_POWER_USAGE{instance_id="$hostname", uuid=~"GPU-.*"}`).
+			Expr(gpuQuery(`DCGM_FI_DEV_POWER_USAGE{%s, uuid=~"GPU-.*"}`)).
 			LegendFormat("{{uuid}}").
 			Range(),
 		).
@@ -750,14 +582,14 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(5).
-		Span(7),
+		Span(10),
 	).
 	WithPanel(stat.NewPanelBuilder().
 		Title("GPU SM Clocks").
 		Description("Displays the current Streaming Multiprocessor (SM) clock frequency in MHz.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`avg(DCGM_FI_DEV_SM_CLOCK{instance_id="$hostname"}) * 1000000`).
+			Expr(`avg(`+gpuQuery(`DCGM_FI_DEV_SM_CLOCK{%s}`)+`) * 1000000`).
 			Instant(),
 		).
 		Unit(units.Hertz).
@@ -777,14 +609,14 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		ColorMode(common.BigValueColorModeNone).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(3).
-		Span(3),
+		Span(12),
 	).
 	WithPanel(stat.NewPanelBuilder().
 		Title("GPU Memory Clocks").
 		Description("Displays the current memory clock frequency of the GPU in GHz.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`avg(DCGM_FI_DEV_MEM_CLOCK{instance_id="$hostname"}) * 1000000`).
+			Expr(`avg(`+gpuQuery(`DCGM_FI_DEV_MEM_CLOCK{%s}`)+`) * 1000000`).
 			Instant(),
 		).
 		Unit(units.Hertz).
@@ -804,8 +636,588 @@ var NebiusGPU = dashboard.NewDashboardBuilder("Nebius GPU").
 		ColorMode(common.BigValueColorModeNone).
 		Thresholds(dashboard.NewThresholdsConfigBuilder()).
 		Height(3).
+		Span(12),
+	).
+	WithRow(dashboard.NewRowBuilder("Energy and cost")).
+	WithPanel(stat.NewPanelBuilder().
+		Title("GPU Energy").
+		Description("Energy consumed by the GPUs within the dashboard time range.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+gpuQuery(gpuEnergy)+`)`).
+			Instant(),
+		).
+		Unit(units.KiloWattHour).
+		Decimals(1).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(5).
 		Span(3),
 	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("GPU Energy Cost").
+		Description("Estimated cost of the GPU energy consumed within the dashboard time range, based on the price per kWh.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+gpuQuery(gpuEnergy)+`) * $price_per_kwh`).
+			Instant(),
+		).
+		Unit(units.Dollars).
+		Decimals(2).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(5).
+		Span(3),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("GPU-hours").
+		Description("GPU time reported by DCGM within the dashboard time range.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(count_over_time(`+gpuQuery(`DCGM_FI_DEV_GPU_UTIL{%s}`)+`[$__range:1m])) / 60`).
+			Instant(),
+		).
+		Unit(units.Short).
+		Decimals(1).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(5).
+		Span(3),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("GPU-hours Cost").
+		Description("Estimated cost of the GPU time within the dashboard time range, based on the price per GPU-hour.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(count_over_time(`+gpuQuery(`DCGM_FI_DEV_GPU_UTIL{%s}`)+`[$__range:1m])) / 60 * $price_per_gpu_hour`).
+			Instant(),
+		).
+		Unit(units.Dollars).
+		Decimals(2).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(5).
+		Span(3),
+	).
+	WithPanel(bargauge.NewPanelBuilder().
+		Title("Energy per GPU").
+		Description("Energy consumed by each GPU within the dashboard time range.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(gpuQuery(gpuEnergy)).
+			LegendFormat("{{uuid}}").
+			Instant(),
+		).
+		Unit(units.KiloWattHour).
+		Decimals(1).
+		Min(0).
+		Orientation(common.VizOrientationHorizontal).
+		DisplayMode(common.BarGaugeDisplayModeGradient).
+		ReduceOptions(common.NewReduceDataOptionsBuilder().
+			Calcs([]string{"lastNotNull"}),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(5).
+		Span(6),
+	).
+	WithPanel(bargauge.NewPanelBuilder().
+		Title("Energy per Host").
+		Description("Energy consumed by the GPUs of each instance within the dashboard time range.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by (instance_id) (`+gpuQuery(gpuEnergy)+`)`).
+			LegendFormat("{{instance_id}}").
+			Instant(),
+		).
+		Unit(units.KiloWattHour).
+		Decimals(1).
+		Min(0).
+		Orientation(common.VizOrientationHorizontal).
+		DisplayMode(common.BarGaugeDisplayModeGradient).
+		ReduceOptions(common.NewReduceDataOptionsBuilder().
+			Calcs([]string{"lastNotNull"}),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(5).
+		Span(6),
+	).
+	WithRow(hostRow("Host")).
+	WithRow(hostLogsRow("Kernel and system logs")).
+	WithRow(dashboard.NewRowBuilder("MIG instances").
+		Collapsed(true).
+		WithPanel(stat.NewPanelBuilder().
+			Title("MIG Instances").
+			Description("Number of Multi-Instance GPU slices exposed by DCGM on the instance. Zero means MIG is disabled and whole GPUs are reported.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`count(DCGM_FI_DEV_FB_USED{instance_id="$hostname", GPU_I_ID!=""}) or on() vector(0)`).
+				Instant(),
+			).
+			Unit(units.Short).
+			ColorMode(common.BigValueColorModeNone).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(3),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("MIG Utilization").
+			Description("Fraction of time the graphics engine of each MIG slice is active. DCGM does not report DCGM_FI_DEV_GPU_UTIL for MIG slices.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`DCGM_FI_PROF_GR_ENGINE_ACTIVE{instance_id="$hostname", GPU_I_ID!=""} and on(uuid, GPU_I_ID) `+migSelected).
+				LegendFormat("{{uuid}} / {{GPU_I_PROFILE}} ({{GPU_I_ID}})").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(7),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("MIG Utilization by GPU").
+			Description("Average graphics engine activity of all MIG slices, grouped by parent GPU.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`avg by (uuid) (DCGM_FI_PROF_GR_ENGINE_ACTIVE{instance_id="$hostname", GPU_I_ID!=""} and on(uuid, GPU_I_ID) `+migSelected+`)`).
+				LegendFormat("{{uuid}}").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(7),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("MIG Framebuffer Usage").
+			Description("Framebuffer memory used by each MIG slice, as a fraction of the memory assigned to the slice.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`(DCGM_FI_DEV_FB_USED{instance_id="$hostname", GPU_I_ID!=""} / (DCGM_FI_DEV_FB_USED{instance_id="$hostname", GPU_I_ID!=""} + DCGM_FI_DEV_FB_FREE{instance_id="$hostname", GPU_I_ID!=""})) and on(uuid, GPU_I_ID) `+migSelected).
+				LegendFormat("{{uuid}} / {{GPU_I_PROFILE}} ({{GPU_I_ID}})").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(7),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("MIG Framebuffer Used").
+			Description("Framebuffer memory used by each MIG slice in bytes. Stacked values add up to the memory in use across the selected slices.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`(DCGM_FI_DEV_FB_USED{instance_id="$hostname", GPU_I_ID!=""} and on(uuid, GPU_I_ID) `+migSelected+`) * 1024 * 1024`).
+				LegendFormat("{{uuid}} / {{GPU_I_PROFILE}} ({{GPU_I_ID}})").
+				Range(),
+			).
+			Unit(units.BytesIEC).
+			Stacking(common.NewStackingConfigBuilder().
+				Mode(common.StackingModeNormal),
+			).
+			FillOpacity(10).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(6),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("MIG SM Activity").
+			Description("Fraction of time at least one warp is active on the streaming multiprocessors of each MIG slice.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`DCGM_FI_PROF_SM_ACTIVE{instance_id="$hostname", GPU_I_ID!=""} and on(uuid, GPU_I_ID) `+migSelected).
+				LegendFormat("{{uuid}} / {{GPU_I_PROFILE}} ({{GPU_I_ID}})").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(6),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("MIG Tensor Core Activity").
+			Description("Fraction of cycles the tensor pipes of each MIG slice are active.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`DCGM_FI_PROF_PIPE_TENSOR_ACTIVE{instance_id="$hostname", GPU_I_ID!=""} and on(uuid, GPU_I_ID) `+migSelected).
+				LegendFormat("{{uuid}} / {{GPU_I_PROFILE}} ({{GPU_I_ID}})").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(6),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("MIG DRAM Activity").
+			Description("Fraction of cycles the device memory interface of each MIG slice is sending or receiving data.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`DCGM_FI_PROF_DRAM_ACTIVE{instance_id="$hostname", GPU_I_ID!=""} and on(uuid, GPU_I_ID) `+migSelected).
+				LegendFormat("{{uuid}} / {{GPU_I_PROFILE}} ({{GPU_I_ID}})").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			Min(0).
+			Max(1).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(6),
+		),
+	).
+	WithRow(dashboard.NewRowBuilder("GPU usage by workload").
+		Collapsed(true).
+		WithPanel(table.NewPanelBuilder().
+			Title("GPU Allocation").
+			Description("Kubernetes containers that have GPUs of the instance allocated, as reported by the DCGM exporter, with current GPU utilization.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`max by (gpu, uuid, namespace, pod, container) (DCGM_FI_DEV_GPU_UTIL{instance_id="$hostname", namespace=~"$namespace", pod=~"$pod"})`).
+				Format(prometheus.PromQueryFormatTable).
+				Instant(),
+			).
+			WithTransformation(organizeFields(
+				[]string{"Time"},
+				map[string]string{
+					"gpu":       "GPU",
+					"uuid":      "UUID",
+					"namespace": "Namespace",
+					"pod":       "Pod",
+					"container": "Container",
+					"Value":     "Utilization",
+				},
+			)).
+			OverrideByName("Utilization", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.Percent},
+			}).
+			SortBy([]cog.Builder[common.TableSortByFieldState]{
+				common.NewTableSortByFieldStateBuilder().
+					DisplayName("GPU"),
+			}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(24),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("GPU Utilization by Pod").
+			Description("Average utilization of the GPUs allocated to each pod.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`avg by (namespace, pod) (DCGM_FI_DEV_GPU_UTIL{instance_id="$hostname", namespace=~"$namespace", pod=~"$pod"})`).
+				LegendFormat("{{namespace}}/{{pod}}").
+				Range(),
+			).
+			Unit(units.Percent).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(8),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("GPU Memory Used by Pod").
+			Description("Framebuffer memory used on the GPUs allocated to each pod.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (namespace, pod) (DCGM_FI_DEV_FB_USED{instance_id="$hostname", namespace=~"$namespace", pod=~"$pod"}) * 1024 * 1024`).
+				LegendFormat("{{namespace}}/{{pod}}").
+				Range(),
+			).
+			Unit(units.BytesIEC).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(8),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("GPU Power by Pod").
+			Description("Combined power consumption of the GPUs allocated to each pod, in watts.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (namespace, pod) (DCGM_FI_DEV_POWER_USAGE{instance_id="$hostname", namespace=~"$namespace", pod=~"$pod"})`).
+				LegendFormat("{{namespace}}/{{pod}}").
+				Range(),
+			).
+			Unit(units.Watt).
+			Stacking(common.NewStackingConfigBuilder().
+				Mode(common.StackingModeNormal),
+			).
+			FillOpacity(10).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(8),
+		),
+	).
+	WithRow(dashboard.NewRowBuilder("Slurm job $slurm_job").
+		Collapsed(true).
+		WithPanel(stat.NewPanelBuilder().
+			Title("Job GPUs").
+			Description("Number of GPUs allocated to the selected Slurm job.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`count(`+slurmJobGPUs+`)`).
+				Instant(),
+			).
+			Unit(units.Short).
+			ColorMode(common.BigValueColorModeNone).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(3),
+		).
+		WithPanel(stat.NewPanelBuilder().
+			Title("Job Nodes").
+			Description("Number of instances the GPUs of the selected Slurm job are located on.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`count(count by (instance_id) (DCGM_FI_DEV_GPU_UTIL and on(uuid) `+slurmJobGPUs+`))`).
+				Instant(),
+			).
+			Unit(units.Short).
+			ColorMode(common.BigValueColorModeNone).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(3),
+		).
+		WithPanel(stat.NewPanelBuilder().
+			Title("GPU-hours").
+			Description("GPU time allocated to the selected Slurm job within the dashboard time range.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(count_over_time(`+slurmJobGPUs+`[$__range:1m])) / 60`).
+				Instant(),
+			).
+			Unit(units.Short).
+			Decimals(1).
+			ColorMode(common.BigValueColorModeNone).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(3),
+		).
+		WithPanel(gauge.NewPanelBuilder().
+			Title("Job Avg. GPU Utilization").
+			Description("Average utilization of the GPUs of the selected Slurm job while they were allocated to it within the dashboard time range.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`avg(avg_over_time((DCGM_FI_DEV_GPU_UTIL and on(uuid) `+slurmJobGPUs+`)[$__range:1m]))`).
+				Instant(),
+			).
+			Unit(units.Percent).
+			Min(0).
+			Max(100).
+			Mappings([]dashboard.ValueMapping{
+				{
+					SpecialValueMap: &dashboard.SpecialValueMap{
+						Type: dashboard.MappingTypeSpecialValue,
+						Options: dashboard.DashboardSpecialValueMapOptions{
+							Match: dashboard.SpecialValueMatchNull,
+							Result: dashboard.ValueMappingResult{
+								Text: New("N/A"),
+							},
+						},
+					},
+				},
+			}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(212, 74, 58)",
+					},
+					{
+						Value: New(50.0),
+						Color: "rgb(237, 129, 40)",
+					},
+					{
+						Value: New(80.0),
+						Color: "rgb(41, 156, 70)",
+					},
+				}),
+			).
+			Height(5).
+			Span(3),
+		).
+		WithPanel(stat.NewPanelBuilder().
+			Title("Job Energy").
+			Description("Energy consumed by the GPUs of the selected Slurm job while they were allocated to it within the dashboard time range.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(sum_over_time((`+fmt.Sprintf(gpuMinuteEnergy, `uuid!=""`)+` and on(uuid) `+slurmJobGPUs+`)[$__range:1m]))`).
+				Instant(),
+			).
+			Unit(units.KiloWattHour).
+			Decimals(1).
+			ColorMode(common.BigValueColorModeNone).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(3),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("Job GPU Utilization by Node").
+			Description("Average utilization of the GPUs of the selected Slurm job on each of its nodes.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`avg by (instance_id) (DCGM_FI_DEV_GPU_UTIL and on(uuid) `+slurmJobGPUs+`)`).
+				LegendFormat("{{instance_id}}").
+				Range(),
+			).
+			Unit(units.Percent).
+			LineWidth(2).
+			ShowPoints(common.VisibilityModeNever).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(5).
+			Span(9),
+		),
+	).
+	Annotation(grafanaAlertsAnnotation()).
+	Annotation(firingAlertsAnnotation(`instance_id="$hostname"`)).
+	Annotation(nodeRebootsAnnotation(`instance_id="$hostname"`)).
+	Annotation(kernelErrorsAnnotation()).
 	Time("now-24h", "now").
 	Refresh("1m").
 	Readonly()
+
+// MIG instance IDs are only unique within their parent GPU, so the mig variable
+// holds uuid/GPU_I_ID pairs. migSelected has the uuid and GPU_I_ID labels of
+// the selected instances.
+const (
+	migInstances = `label_join(DCGM_FI_DEV_FB_USED{instance_id="$hostname", GPU_I_ID!=""}, "mig", "/", "uuid", "GPU_I_ID")`
+	migSelected  = `(label_replace(` + migInstances + `, "mig", "selected", "mig", "$mig") and on(mig) label_replace(vector(1), "mig", "selected", "", ""))`
+)
+
+// gpuPrices are the default prices in USD used to estimate the cost. They are
+// exposed as dashboard variables to be adjusted to the actual prices.
+var gpuPrices = struct {
+	KWh     float64 // per kWh
+	GPUHour float64 // per GPU-hour, on-demand NVIDIA H100
+}{
+	KWh:     0.12,
+	GPUHour: 2.95,
+}
+
+// slurmJobGPUs selects the GPUs allocated to the selected Slurm job, relabeled
+// to match the uuid label of DCGM metrics.
+const slurmJobGPUs = `label_replace(slurm_job_gpu_info{job_id="$slurm_job"}, "uuid", "$1", "gpu_uuid", "(.+)")`
+
+// gpuEnergy is the energy consumed by a GPU within the dashboard time range in
+// kWh. DCGM reports the energy counter in millijoules; GPUs without the counter
+// fall back to integrating the power usage over one-minute steps.
+const gpuEnergy = `(increase(DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION{%[1]s}[$__range]) / 3600000000 or sum_over_time(DCGM_FI_DEV_POWER_USAGE{%[1]s}[$__range:1m]) / 60000)`
+
+// gpuMinuteEnergy is the energy consumed by a GPU within the last minute in
+// kWh, with the same fallback as gpuEnergy.
+const gpuMinuteEnergy = `(increase(DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION{%[1]s}[1m]) / 3600000000 or DCGM_FI_DEV_POWER_USAGE{%[1]s} / 60000)`
+
+// gpuModel holds the limits of a GPU model, used when DCGM does not export them.
+type gpuModel struct {
+	// Name is the exact modelName label of DCGM metrics.
+	Name string
+	// PowerLimit is the board power limit in watts.
+	PowerLimit float64
+	// MaxOperatingTemp is the maximum operating temperature in degrees Celsius.
+	MaxOperatingTemp float64
+}
+
+var gpuModels = []gpuModel{
+	{Name: "NVIDIA H100 80GB HBM3", PowerLimit: 700, MaxOperatingTemp: 87},
+	{Name: "NVIDIA H100 PCIe", PowerLimit: 350, MaxOperatingTemp: 87},
+	{Name: "NVIDIA H200", PowerLimit: 700, MaxOperatingTemp: 87},
+	{Name: "NVIDIA B200", PowerLimit: 1000, MaxOperatingTemp: 87},
+	{Name: "NVIDIA L40S", PowerLimit: 350, MaxOperatingTemp: 88},
+}
+
+var (
+	gpuPowerLimit       = gpuLimit("DCGM_FI_DEV_POWER_MGMT_LIMIT", "DCGM_FI_DEV_POWER_USAGE", func(m gpuModel) float64 { return m.PowerLimit })
+	gpuMaxOperatingTemp = gpuLimit("DCGM_FI_DEV_GPU_MAX_OP_TEMP", "DCGM_FI_DEV_GPU_TEMP", func(m gpuModel) float64 { return m.MaxOperatingTemp })
+)
+
+// gpuLimit renders a per-GPU query format for a limit exported by DCGM as
+// metric. GPUs without the metric fall back to the value of their model in
+// gpuModels, for the GPUs that report the measured metric the limit applies
+// to.
+func gpuLimit(metric, measured string, value func(gpuModel) float64) string {
+	parts := []string{metric + "{%[1]s}"}
+	for _, m := range gpuModels {
+		parts = append(parts, fmt.Sprintf(`%g * group by (uuid) (%s{%%[1]s, modelName="%s"}) unless on(uuid) %s{%%[1]s}`, value(m), measured, m.Name, metric))
+	}
+	return "(" + strings.Join(parts, " or ") + ")"
+}
+
+// gpuQuery renders a per-GPU query for the GPUs of the selected Slurm job, or
+// for the GPUs of the selected instance when no job is selected. Every %s verb
+// in format is replaced with the label matchers of the selection.
+func gpuQuery(format string) string {
+	return fmt.Sprintf(`(%s and on(uuid) %s or %s unless on() slurm_job_gpu_info{job_id="$slurm_job"})`,
+		fmt.Sprintf(format, `uuid!=""`),
+		slurmJobGPUs,
+		fmt.Sprintf(format, `instance_id="$hostname"`),
+	)
+}
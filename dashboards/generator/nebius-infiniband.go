@@ -0,0 +1,496 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
+	"github.com/grafana/grafana-foundation-sdk/go/common"
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/stat"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
+	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
+	"github.com/grafana/grafana-foundation-sdk/go/units"
+)
+
+const infinibandSelector = `instance_id=~"$hostname", device=~"$device"`
+
+var NebiusInfiniBand = dashboard.NewDashboardBuilder("Nebius InfiniBand").
+	Uid("nebius-infiniband").
+	Description("Dashboard provides per-port monitoring of the InfiniBand fabric of Nebius GPU clusters.").
+	Tags([]string{"Nebius", "Compute", "GPU", "InfiniBand"}).
+	Link(dashboard.NewDashboardLinkBuilder("Docs").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("https://docs.nebius.com/observability").
+		TargetBlank(true).
+		Icon("doc"),
+	).
+	Link(dashboard.NewDashboardLinkBuilder("GitHub").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("https://github.com/nebius/observability").
+		TargetBlank(true).
+		Icon("external link"),
+	).
+	WithVariable(
+		DatasourceVar,
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("hostname").
+			Label("instance").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New("label_values(node_infiniband_state_id, instance_id)"),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".*").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("device").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New(`label_values(node_infiniband_state_id{instance_id=~"$hostname"}, device)`),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".*").
+			AllowCustomValue(false),
+	).
+	WithRow(dashboard.NewRowBuilder("Port health")).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Active ports").
+		Description("Number of InfiniBand ports in the Active logical state.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`count(node_infiniband_state_id{`+infinibandSelector+`} == 4) or on() vector(0)`).
+			Instant(),
+		).
+		Unit(units.Short).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(4).
+		Span(4),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Inactive ports").
+		Description("Number of InfiniBand ports that are not in the Active logical state.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`count(node_infiniband_state_id{`+infinibandSelector+`} != 4) or on() vector(0)`).
+			Instant(),
+		).
+		Unit(units.Short).
+		ColorMode(common.BigValueColorModeBackground).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(41, 156, 70)",
+				},
+				{
+					Value: New(1.0),
+					Color: "rgb(212, 74, 58)",
+				},
+			}),
+		).
+		Height(4).
+		Span(4),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Ports with errors").
+		Description("Number of InfiniBand ports that reported symbol errors, receive errors, transmit discards or link downs within the dashboard time range.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`count(sum by (instance_id, device, port) (increase({__name__=~"node_infiniband_(symbol_error|link_downed|port_errors_received|port_discards_transmitted)_total", `+infinibandSelector+`}[$__range])) > 0) or on() vector(0)`).
+			Instant(),
+		).
+		Unit(units.Short).
+		ColorMode(common.BigValueColorModeBackground).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(41, 156, 70)",
+				},
+				{
+					Value: New(1.0),
+					Color: "rgb(237, 129, 40)",
+				},
+			}),
+		).
+		Height(4).
+		Span(4),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Link downed").
+		Description("Number of times InfiniBand links went down within the dashboard time range.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(increase(node_infiniband_link_downed_total{`+infinibandSelector+`}[$__range])) or on() vector(0)`).
+			Instant(),
+		).
+		Unit(units.Short).
+		Decimals(0).
+		ColorMode(common.BigValueColorModeBackground).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(41, 156, 70)",
+				},
+				{
+					Value: New(1.0),
+					Color: "rgb(212, 74, 58)",
+				},
+			}),
+		).
+		Height(4).
+		Span(4),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Total throughput").
+		Description("Combined transmit and receive rate of all selected InfiniBand ports.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+infinibandBytes("transmitted", infinibandSelector)+`) + sum(`+infinibandBytes("received", infinibandSelector)+`)`).
+			Instant(),
+		).
+		Unit(units.BytesPerSecondSI).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(4).
+		Span(8),
+	).
+	WithPanel(table.NewPanelBuilder().
+		Title("Port health").
+		Description("State, rate and error counters of every InfiniBand port within the dashboard time range. Symbol errors and transmit waits on a single port usually point to the cable or switch port slowing down NCCL collectives.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`max by (instance_id, device, port) (node_infiniband_state_id{`+infinibandSelector+`})`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("State").
+			Instant(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`max by (instance_id, device, port) (node_infiniband_physical_state_id{`+infinibandSelector+`})`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Physical state").
+			Instant(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`max by (instance_id, device, port) (node_infiniband_rate_bytes_per_second{`+infinibandSelector+`})`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Rate").
+			Instant(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by (instance_id, device, port) (increase(node_infiniband_symbol_error_total{`+infinibandSelector+`}[$__range]))`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Symbol errors").
+			Instant(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by (instance_id, device, port) (increase(node_infiniband_link_downed_total{`+infinibandSelector+`}[$__range]))`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Link downed").
+			Instant(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by (instance_id, device, port) (increase(node_infiniband_port_errors_received_total{`+infinibandSelector+`}[$__range]))`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Receive errors").
+			Instant(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by (instance_id, device, port) (increase(node_infiniband_port_discards_transmitted_total{`+infinibandSelector+`}[$__range]))`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Transmit discards").
+			Instant(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by (instance_id, device, port) (increase(node_infiniband_port_transmit_wait_total{`+infinibandSelector+`}[$__range]))`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Transmit wait").
+			Instant(),
+		).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id:      "merge",
+			Options: map[string]any{},
+		}).
+		WithTransformation(organizeFields(
+			[]string{"Time"},
+			map[string]string{
+				"instance_id":              "Instance",
+				"device":                   "Device",
+				"port":                     "Port",
+				"Value #State":             "State",
+				"Value #Physical state":    "Physical state",
+				"Value #Rate":              "Rate",
+				"Value #Symbol errors":     "Symbol errors",
+				"Value #Link downed":       "Link downed",
+				"Value #Receive errors":    "Receive errors",
+				"Value #Transmit discards": "Transmit discards",
+				"Value #Transmit wait":     "Transmit wait",
+			},
+		)).
+		OverrideByName("State", []dashboard.DynamicConfigValue{
+			{Id: "mappings", Value: infinibandStateMappings},
+			{Id: "custom.cellOptions", Value: map[string]string{"type": string(common.TableCellDisplayModeColorText)}},
+		}).
+		OverrideByName("Physical state", []dashboard.DynamicConfigValue{
+			{Id: "mappings", Value: infinibandPhysicalStateMappings},
+			{Id: "custom.cellOptions", Value: map[string]string{"type": string(common.TableCellDisplayModeColorText)}},
+		}).
+		OverrideByName("Rate", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.BytesPerSecondSI},
+		}).
+		OverrideByRegexp("Symbol errors|Link downed|Receive errors|Transmit discards|Transmit wait", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.Short},
+			{Id: "decimals", Value: 0},
+			{Id: "custom.cellOptions", Value: map[string]string{"type": string(common.TableCellDisplayModeColorBackground)}},
+			{Id: "thresholds", Value: dashboard.ThresholdsConfig{
+				Mode: dashboard.ThresholdsModeAbsolute,
+				Steps: []dashboard.Threshold{
+					{Color: "transparent"},
+					{Value: New(1.0), Color: "rgb(212, 74, 58)"},
+				},
+			}},
+		}).
+		SortBy([]cog.Builder[common.TableSortByFieldState]{
+			common.NewTableSortByFieldStateBuilder().
+				DisplayName("Symbol errors").
+				Desc(true),
+		}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(10).
+		Span(24),
+	).
+	WithRow(dashboard.NewRowBuilder("Throughput")).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Port throughput").
+		Description("Data transfer rates of each InfiniBand port, transmitted (Out) and received (In).").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(infinibandBytes("transmitted", infinibandSelector)).
+			LegendFormat("{{instance_id}} {{device}}:{{port}} Out").
+			Range(),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(infinibandBytes("received", infinibandSelector)).
+			LegendFormat("{{instance_id}} {{device}}:{{port}} In").
+			Range(),
+		).
+		OverrideByQuery("B", []dashboard.DynamicConfigValue{
+			{
+				Id:    "custom.transform",
+				Value: common.GraphTransformNegativeY,
+			},
+		}).
+		Unit(units.BytesPerSecondSI).
+		FillOpacity(10).
+		LineWidth(2).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Legend(common.NewVizLegendOptionsBuilder().
+			Calcs([]string{"mean", "max"}).
+			DisplayMode(common.LegendDisplayModeTable).
+			ShowLegend(true),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(9).
+		Span(16),
+	).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Link rate").
+		Description("Negotiated link rate of each InfiniBand port. A port running below the rate of its peers indicates a degraded cable or transceiver.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`node_infiniband_rate_bytes_per_second{`+infinibandSelector+`}`).
+			LegendFormat("{{instance_id}} {{device}}:{{port}}").
+			Range(),
+		).
+		Unit(units.BytesPerSecondSI).
+		LineWidth(2).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderNone),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(9).
+		Span(8),
+	).
+	WithRow(dashboard.NewRowBuilder("Errors")).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Symbol errors").
+		Description("Rate of minor link errors detected on the physical lanes of each port. A steady rate usually indicates a bad cable or transceiver.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`rate(node_infiniband_symbol_error_total{`+infinibandSelector+`}[$__rate_interval]) > 0`).
+			LegendFormat("{{instance_id}} {{device}}:{{port}}").
+			Range(),
+		).
+		Unit(units.Short).
+		LineWidth(2).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(12),
+	).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Link downed").
+		Description("Number of times the link of each port failed the error recovery process and went down.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`increase(node_infiniband_link_downed_total{`+infinibandSelector+`}[$__rate_interval]) > 0`).
+			LegendFormat("{{instance_id}} {{device}}:{{port}}").
+			Range(),
+		).
+		Unit(units.Short).
+		DrawStyle(common.GraphDrawStyleBars).
+		FillOpacity(80).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(12),
+	).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Receive errors").
+		Description("Rate of packets received with errors on each port (port_rcv_errors).").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`rate(node_infiniband_port_errors_received_total{`+infinibandSelector+`}[$__rate_interval]) > 0`).
+			LegendFormat("{{instance_id}} {{device}}:{{port}}").
+			Range(),
+		).
+		Unit(units.PacketsPerSecond).
+		LineWidth(2).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(12),
+	).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Transmit discards").
+		Description("Rate of outbound packets discarded by each port because it was down or congested (xmit_discards).").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`rate(node_infiniband_port_discards_transmitted_total{`+infinibandSelector+`}[$__rate_interval]) > 0`).
+			LegendFormat("{{instance_id}} {{device}}:{{port}}").
+			Range(),
+		).
+		Unit(units.PacketsPerSecond).
+		LineWidth(2).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(12),
+	).
+	WithRow(dashboard.NewRowBuilder("Congestion")).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Transmit wait").
+		Description("Rate of ticks during which each port had data to transmit but no flow control credits (xmit_wait). Sustained waits on a subset of ports point to fabric congestion slowing down collectives.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`rate(node_infiniband_port_transmit_wait_total{`+infinibandSelector+`}[$__rate_interval])`).
+			LegendFormat("{{instance_id}} {{device}}:{{port}}").
+			Range(),
+		).
+		Unit(units.Short).
+		LineWidth(2).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Legend(common.NewVizLegendOptionsBuilder().
+			Calcs([]string{"mean", "max"}).
+			DisplayMode(common.LegendDisplayModeTable).
+			ShowLegend(true),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(9).
+		Span(16),
+	).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Transmit wait by instance").
+		Description("Transmit wait ticks summed over all ports of each instance.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by (instance_id) (rate(node_infiniband_port_transmit_wait_total{`+infinibandSelector+`}[$__rate_interval]))`).
+			LegendFormat("{{instance_id}}").
+			Range(),
+		).
+		Unit(units.Short).
+		LineWidth(2).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(9).
+		Span(8),
+	).
+	Annotation(grafanaAlertsAnnotation()).
+	Annotation(firingAlertsAnnotation(`instance_id=~"$hostname"`)).
+	Annotation(nodeRebootsAnnotation(`instance_id=~"$hostname"`)).
+	Time("now-6h", "now").
+	Refresh("1m").
+	Readonly()
+
+var infinibandStateMappings = []dashboard.ValueMapping{
+	{
+		ValueMap: &dashboard.ValueMap{
+			Type: dashboard.MappingTypeValueToText,
+			Options: map[string]dashboard.ValueMappingResult{
+				"1": {Text: New("Down"), Color: New("red")},
+				"2": {Text: New("Initializing"), Color: New("orange")},
+				"3": {Text: New("Armed"), Color: New("orange")},
+				"4": {Text: New("Active"), Color: New("green")},
+				"5": {Text: New("Active deferred"), Color: New("orange")},
+			},
+		},
+	},
+}
+
+var infinibandPhysicalStateMappings = []dashboard.ValueMapping{
+	{
+		ValueMap: &dashboard.ValueMap{
+			Type: dashboard.MappingTypeValueToText,
+			Options: map[string]dashboard.ValueMappingResult{
+				"1": {Text: New("Sleep"), Color: New("red")},
+				"2": {Text: New("Polling"), Color: New("orange")},
+				"3": {Text: New("Disabled"), Color: New("red")},
+				"4": {Text: New("Training"), Color: New("orange")},
+				"5": {Text: New("LinkUp"), Color: New("green")},
+				"6": {Text: New("Error recovery"), Color: New("orange")},
+				"7": {Text: New("PHY test"), Color: New("orange")},
+			},
+		},
+	},
+}
+
+// infinibandBytes renders the rate of the transmitted or received data counter
+// of the InfiniBand ports matched by selector. Counters of hfi devices are
+// scaled by two.
+func infinibandBytes(direction, selector string) string {
+	return fmt.Sprintf(`irate(node_infiniband_port_data_%[1]s_bytes_total{%[2]s, device!~"hfi.+"}[$__interval]) or irate(node_infiniband_port_data_%[1]s_bytes_total{%[2]s, device=~"hfi.+"}[$__interval]) * 2`, direction, selector)
+}
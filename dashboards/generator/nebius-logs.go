@@ -0,0 +1,259 @@
+package main
+
+import (
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
+	"github.com/grafana/grafana-foundation-sdk/go/common"
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/logs"
+	"github.com/grafana/grafana-foundation-sdk/go/loki"
+	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
+	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
+	"github.com/grafana/grafana-foundation-sdk/go/units"
+)
+
+// Labels set on Kubernetes logs by the k8sattributes processor of the
+// otel-collector chart. The log level is detected by Nebius Logging. Nodes are
+// mapped to their compute instances by the host name in node_uname_info.
+const (
+	logsSelector = `{k8s_namespace_name=~"$namespace", k8s_pod_name=~"$pod", k8s_container_name=~"$container", k8s_node_name=~"$node"}`
+	logsFilter   = `| detected_level=~"$severity" |~ "(?i)$search"`
+	logsErrors   = `| detected_level=~"error|fatal|critical"`
+)
+
+var NebiusLogs = dashboard.NewDashboardBuilder("Nebius Logs").
+	Uid("nebius-logs").
+	Description("Dashboard to explore Kubernetes logs stored in Nebius Logging.").
+	Tags([]string{"Nebius", "Logging"}).
+	Link(dashboard.NewDashboardLinkBuilder("Docs").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("https://docs.nebius.com/observability").
+		TargetBlank(true).
+		Icon("doc"),
+	).
+	Link(dashboard.NewDashboardLinkBuilder("GitHub").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("https://github.com/nebius/observability").
+		TargetBlank(true).
+		Icon("external link"),
+	).
+	WithVariable(
+		DatasourceLoggingVar,
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("namespace").
+			Datasource(DatasourceLoggingRef).
+			Query(dashboard.StringOrMap{
+				String: New("label_values(k8s_namespace_name)"),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".+").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("pod").
+			Datasource(DatasourceLoggingRef).
+			Query(dashboard.StringOrMap{
+				String: New(`label_values({k8s_namespace_name=~"$namespace"}, k8s_pod_name)`),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".+").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("container").
+			Datasource(DatasourceLoggingRef).
+			Query(dashboard.StringOrMap{
+				String: New(`label_values({k8s_namespace_name=~"$namespace", k8s_pod_name=~"$pod"}, k8s_container_name)`),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".+").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("node").
+			Description("Kubernetes nodes the pods run on.").
+			Datasource(DatasourceLoggingRef).
+			Query(dashboard.StringOrMap{
+				String: New(`label_values({k8s_namespace_name=~"$namespace", k8s_pod_name=~"$pod"}, k8s_node_name)`),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".*").
+			AllowCustomValue(false),
+	).
+	WithVariable(
+		dashboard.NewCustomVariableBuilder("severity").
+			Values(dashboard.StringOrMap{
+				String: New("trace,debug,info,warn,error,fatal,critical,unknown"),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".*"),
+	).
+	WithVariable(
+		dashboard.NewTextBoxVariableBuilder("search").
+			Label("Search").
+			Description("Case-insensitive regular expression to filter log lines by.").
+			DefaultValue(dashboard.StringOrMap{
+				String: New(""),
+			}),
+	).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Log volume by level").
+		Description("Number of log lines matching the filters, by detected log level.").
+		Datasource(DatasourceLoggingRef).
+		WithTarget(loki.NewDataqueryBuilder().
+			Expr(`sum by(detected_level) (count_over_time(`+logsSelector+` `+logsFilter+` [$__auto]))`).
+			LegendFormat("{{detected_level}}").
+			Range(true),
+		).
+		Unit(units.Short).
+		DrawStyle(common.GraphDrawStyleBars).
+		FillOpacity(80).
+		Stacking(common.NewStackingConfigBuilder().
+			Mode(common.StackingModeNormal),
+		).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		OverrideByRegexp("trace|debug", []dashboard.DynamicConfigValue{fixedColor("blue")}).
+		OverrideByName("info", []dashboard.DynamicConfigValue{fixedColor("rgb(41, 156, 70)")}).
+		OverrideByName("warn", []dashboard.DynamicConfigValue{fixedColor("rgb(237, 129, 40)")}).
+		OverrideByRegexp("error|fatal|critical", []dashboard.DynamicConfigValue{fixedColor("rgb(212, 74, 58)")}).
+		OverrideByName("unknown", []dashboard.DynamicConfigValue{fixedColor("text")}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(24),
+	).
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Error rate by namespace").
+		Description("Number of error, fatal and critical log lines per second, by Kubernetes namespace.").
+		Datasource(DatasourceLoggingRef).
+		WithTarget(loki.NewDataqueryBuilder().
+			Expr(`sum by(k8s_namespace_name) (rate(`+logsSelector+` `+logsErrors+` [$__auto]))`).
+			LegendFormat("{{k8s_namespace_name}}").
+			Range(true),
+		).
+		Unit(units.Short).
+		LineWidth(1).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(14),
+	).
+	WithPanel(table.NewPanelBuilder().
+		Title("Nodes").
+		Description("Kubernetes nodes that produced the logs matching the filters within the dashboard time range, with links to the host and GPU dashboards of their compute instances.").
+		Datasource(dashboard.DataSourceRef{
+			Type: New("datasource"),
+			Uid:  New("-- Mixed --"),
+		}).
+		WithTarget(loki.NewDataqueryBuilder().
+			Datasource(DatasourceLoggingRef).
+			Expr(`sum by(k8s_node_name) (count_over_time(`+logsSelector+` `+logsFilter+` [$__range]))`).
+			RefId("Lines").
+			Instant(true),
+		).
+		WithTarget(loki.NewDataqueryBuilder().
+			Datasource(DatasourceLoggingRef).
+			Expr(`sum by(k8s_node_name) (count_over_time(`+logsSelector+` `+logsErrors+` [$__range]))`).
+			RefId("Errors").
+			Instant(true),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Datasource(DatasourceRef).
+			Expr(`label_replace(group by(nodename, instance_id) (node_uname_info), "k8s_node_name", "$1", "nodename", "(.+)")`).
+			Format(prometheus.PromQueryFormatTable).
+			RefId("Instance").
+			Instant(),
+		).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id:      "labelsToFields",
+			Options: map[string]any{},
+		}).
+		WithTransformation(organizeFields(
+			[]string{"Time", "nodename", "Value", "Value #Instance"},
+			map[string]string{},
+		)).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id:      "merge",
+			Options: map[string]any{},
+		}).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id: "filterByValue",
+			Options: map[string]any{
+				"type":  "include",
+				"match": "any",
+				"filters": []map[string]any{
+					{"fieldName": "Value #Lines", "config": map[string]any{"id": "isNotNull"}},
+				},
+			},
+		}).
+		WithTransformation(organizeFields(
+			nil,
+			map[string]string{
+				"k8s_node_name": "Node",
+				"instance_id":   "Instance",
+				"Value #Lines":  "Lines",
+				"Value #Errors": "Errors",
+			},
+		)).
+		OverrideByName("Instance", []dashboard.DynamicConfigValue{
+			{Id: "links", Value: []map[string]string{
+				{
+					"title": "Host",
+					"url":   "/d/nebius-compute?var-hostname=${__value.raw}&${__url_time_range}",
+				},
+				{
+					"title": "GPU",
+					"url":   "/d/nebius-gpu?var-hostname=${__value.raw}&${__url_time_range}",
+				},
+			}},
+		}).
+		OverrideByName("Errors", []dashboard.DynamicConfigValue{
+			{Id: "custom.cellOptions", Value: map[string]string{"type": "color-text"}},
+			{Id: "thresholds", Value: dashboard.ThresholdsConfig{
+				Mode: dashboard.ThresholdsModeAbsolute,
+				Steps: []dashboard.Threshold{
+					{Color: "rgb(41, 156, 70)"},
+					{Value: New(1.0), Color: "rgb(212, 74, 58)"},
+				},
+			}},
+		}).
+		SortBy([]cog.Builder[common.TableSortByFieldState]{
+			common.NewTableSortByFieldStateBuilder().
+				DisplayName("Errors").
+				Desc(true),
+		}).
+		Height(8).
+		Span(10),
+	).
+	WithPanel(logs.NewPanelBuilder().
+		Title("Logs").
+		Description("Log lines matching the filters. Use the search variable to filter them by a regular expression.").
+		Datasource(DatasourceLoggingRef).
+		WithTarget(loki.NewDataqueryBuilder().
+			Expr(logsSelector+` `+logsFilter).
+			MaxLines(1000).
+			Range(true),
+		).
+		ShowTime(true).
+		WrapLogMessage(true).
+		EnableLogDetails(true).
+		SortOrder(common.LogsSortOrderDescending).
+		DedupStrategy(common.LogsDedupStrategyNone).
+		Height(16).
+		Span(24),
+	).
+	Time("now-1h", "now").
+	Refresh("1m").
+	Readonly()
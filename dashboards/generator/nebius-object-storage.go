@@ -1,9 +1,15 @@
 package main
 
 import (
+	"fmt"
+
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
 	"github.com/grafana/grafana-foundation-sdk/go/common"
 	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/heatmap"
 	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/stat"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
 	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
 	"github.com/grafana/grafana-foundation-sdk/go/units"
 )
@@ -38,6 +44,81 @@ var NebiusObjectStorage = dashboard.NewDashboardBuilder("Nebius Object Storage")
 			IncludeAll(true).
 			AllValue(".*"),
 	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("handler").
+			Label("Handler").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New(`label_values(request_rate{bucket=~"$bucket"}, handler)`),
+			}).
+			Multi(true).
+			AllowCustomValue(false).
+			IncludeAll(true).
+			AllValue(".*"),
+	).
+	WithVariable(
+		dashboard.NewCustomVariableBuilder("top_n").
+			Label("Top N").
+			Description("Number of buckets shown in the fleet tables and repeated in the requests rows.").
+			Values(dashboard.StringOrMap{
+				String: New("5,10,20,50"),
+			}).
+			Current(dashboard.VariableOption{
+				Text: dashboard.StringOrArrayOfString{
+					String: New("10"),
+				},
+				Value: dashboard.StringOrArrayOfString{
+					String: New("10"),
+				},
+			}),
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("top_bucket").
+			Label("Top buckets").
+			Description("Buckets with the most requests within the dashboard time range, limited to Top N, to repeat the requests rows for.").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New(`query_result(topk($top_n, ` + objectStorageBucketRequests + `))`),
+			}).
+			Regex(`/bucket="([^"]+)"/`).
+			Refresh(dashboard.VariableRefreshOnTimeRangeChanged).
+			Current(dashboard.VariableOption{
+				Text: dashboard.StringOrArrayOfString{
+					String: New("All"),
+				},
+				Value: dashboard.StringOrArrayOfString{
+					String: New("$__all"),
+				},
+			}).
+			Multi(true).
+			AllowCustomValue(false).
+			IncludeAll(true),
+	).
+	WithVariable(
+		priceVariable("price_standard", "Standard, GiB-month",
+			"Price in USD of storing 1 GiB for a month in the STANDARD storage class.",
+			objectStoragePrices.Standard),
+	).
+	WithVariable(
+		priceVariable("price_enhanced_throughput", "Enhanced Throughput, GiB-month",
+			"Price in USD of storing 1 GiB for a month in the ENHANCED_THROUGHPUT storage class.",
+			objectStoragePrices.EnhancedThroughput),
+	).
+	WithVariable(
+		priceVariable("price_read_requests", "1000 read requests",
+			"Price in USD of 1000 read requests.",
+			objectStoragePrices.ReadRequests),
+	).
+	WithVariable(
+		priceVariable("price_mutate_requests", "1000 modify requests",
+			"Price in USD of 1000 modify requests.",
+			objectStoragePrices.MutateRequests),
+	).
+	WithVariable(
+		priceVariable("price_egress", "Egress, GiB",
+			"Price in USD of 1 GiB downloaded from the storage.",
+			objectStoragePrices.Egress),
+	).
 
 	// ─────────────────────────────────────────────────────────────────────────────
 	// Storage space row
@@ -154,11 +235,33 @@ var NebiusObjectStorage = dashboard.NewDashboardBuilder("Nebius Object Storage")
 	).
 
 	// ─────────────────────────────────────────────────────────────────────────────
-	// Requests row (repeated per bucket)
+	// Fleet row
+	// ─────────────────────────────────────────────────────────────────────────────
+	WithRow(
+		dashboard.NewRowBuilder("Fleet"),
+	).
+
+	WithPanel(objectStorageTopBuckets("Top buckets by size",
+		"Top N buckets by the space used. Growth is the change of the space used within the dashboard time range.",
+		objectStorageBucketSize, "Size",
+	)).
+
+	WithPanel(objectStorageTopBuckets("Top buckets by growth",
+		"Top N buckets by the change of the space used within the dashboard time range.",
+		objectStorageBucketGrowth, "Growth",
+	)).
+
+	WithPanel(objectStorageTopBuckets("Top buckets by requests",
+		"Top N buckets by the average request rate within the dashboard time range.",
+		objectStorageBucketRequests, "Requests",
+	)).
+
+	// ─────────────────────────────────────────────────────────────────────────────
+	// Requests row (repeated per top bucket)
 	// ─────────────────────────────────────────────────────────────────────────────
 	WithRow(
-		dashboard.NewRowBuilder("Requests for $bucket").
-			Repeat("bucket"),
+		dashboard.NewRowBuilder("Requests for $top_bucket").
+			Repeat("top_bucket"),
 	).
 
 	WithPanel(timeseries.NewPanelBuilder().
@@ -166,7 +269,7 @@ var NebiusObjectStorage = dashboard.NewDashboardBuilder("Nebius Object Storage")
 		Description("Number of requests made to retrieve object content from a bucket. ").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum by(handler) (rate(request_rate{bucket="$bucket", operation_type="read"}[$__rate_interval]))`).
+			Expr(`sum by(handler) (rate(request_rate{bucket="$top_bucket", operation_type="read"}[$__rate_interval]))`).
 			LegendFormat("{{handler}}").
 			RefId("A"),
 		).
@@ -183,7 +286,7 @@ var NebiusObjectStorage = dashboard.NewDashboardBuilder("Nebius Object Storage")
 		Description("Number of requests made to upload objects or modify object content. ").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum by(handler) (rate(request_rate{bucket="$bucket", operation_type="mutate"}[$__rate_interval]))`).
+			Expr(`sum by(handler) (rate(request_rate{bucket="$top_bucket", operation_type="mutate"}[$__rate_interval]))`).
 			LegendFormat("{{handler}}").
 			RefId("A"),
 		).
@@ -200,7 +303,7 @@ var NebiusObjectStorage = dashboard.NewDashboardBuilder("Nebius Object Storage")
 		Description("Number of errors when accessing S3 API. Number of errors per 5 minutes.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum by(handler, http_code, api_error_code) (increase(http_errors_total{bucket="$bucket"}[5m]))`).
+			Expr(`sum by(handler, http_code, api_error_code) (increase(http_errors_total{bucket="$top_bucket"}[5m]))`).
 			LegendFormat("{{handler}}:{{http_code}}:{{api_error_code}}").
 			RefId("A"),
 		).
@@ -212,6 +315,277 @@ var NebiusObjectStorage = dashboard.NewDashboardBuilder("Nebius Object Storage")
 		Span(8),
 	).
 
+	// ─────────────────────────────────────────────────────────────────────────────
+	// API errors row
+	// ─────────────────────────────────────────────────────────────────────────────
+	WithRow(
+		dashboard.NewRowBuilder("API errors"),
+	).
+
+	WithPanel(stat.NewPanelBuilder().
+		Title("Availability").
+		Description("Share of requests to the selected buckets that did not fail with a server error (5xx) within the dashboard time range. Client errors and SlowDown responses do not consume the error budget.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`1 - (sum(increase(http_errors_total{bucket=~"$bucket", `+objectStorageServerErrors+`}[$__range])) OR on() vector(0)) / sum(increase(request_rate{bucket=~"$bucket"}[$__range]))`).
+			Instant().
+			RefId("A"),
+		).
+		Unit(units.PercentUnit).
+		Decimals(3).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(212, 74, 58)",
+				},
+				{
+					Value: New(0.99),
+					Color: "rgb(237, 129, 40)",
+				},
+				{
+					Value: New(0.999),
+					Color: "rgb(41, 156, 70)",
+				},
+			}),
+		).
+		Height(8).
+		Span(4),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Client and server errors").
+		Description("Failed requests per second classified into client errors (4xx), server errors (5xx) and throttled requests (SlowDown).").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(rate(http_errors_total{bucket=~"$bucket", `+objectStorageClientErrors+`}[$__rate_interval]))`).
+			LegendFormat("Client (4xx)").
+			RefId("A"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(rate(http_errors_total{bucket=~"$bucket", `+objectStorageServerErrors+`}[$__rate_interval]))`).
+			LegendFormat("Server (5xx)").
+			RefId("B"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(rate(http_errors_total{bucket=~"$bucket", `+objectStorageThrottledErrors+`}[$__rate_interval]))`).
+			LegendFormat("Throttled (SlowDown)").
+			RefId("C"),
+		).
+		Unit(units.RequestsPerSecond).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		OverrideByName("Client (4xx)", []dashboard.DynamicConfigValue{fixedColor("#FFB347")}).
+		OverrideByName("Server (5xx)", []dashboard.DynamicConfigValue{fixedColor("#FF5252")}).
+		OverrideByName("Throttled (SlowDown)", []dashboard.DynamicConfigValue{fixedColor("#FFEE58")}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(10),
+	).
+
+	WithPanel(applyHttpStatusOverrides(
+		timeseries.NewPanelBuilder().
+			Title("Errors by status code").
+			Description("Failed requests per second by HTTP status code.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by(http_code) (rate(http_errors_total{bucket=~"$bucket"}[$__rate_interval]))`).
+				LegendFormat("{{http_code}}").
+				RefId("A"),
+			).
+			Unit(units.RequestsPerSecond).
+			FillOpacity(5).
+			ShowPoints(common.VisibilityModeNever).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(10),
+	)).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Error ratio by handler").
+		Description("Share of failed requests by S3 handler.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by(handler) (rate(http_errors_total{bucket=~"$bucket", handler=~"$handler"}[$__rate_interval])) / sum by(handler) (rate(request_rate{bucket=~"$bucket", handler=~"$handler"}[$__rate_interval]))`).
+			LegendFormat("{{handler}}").
+			RefId("A"),
+		).
+		Unit(units.PercentUnit).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Client errors by code").
+		Description("Client errors (4xx) per second by S3 error code, such as AccessDenied or NoSuchKey.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by(api_error_code) (rate(http_errors_total{bucket=~"$bucket", `+objectStorageClientErrors+`}[$__rate_interval]))`).
+			LegendFormat("{{api_error_code}}").
+			RefId("A"),
+		).
+		Unit(units.RequestsPerSecond).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Throttled requests (SlowDown)").
+		Description("Requests per second rejected with SlowDown by bucket and handler. The client exceeds the request rate allowed for the bucket and should retry with backoff or spread the load over more prefixes.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by(bucket, handler) (rate(http_errors_total{bucket=~"$bucket", `+objectStorageThrottledErrors+`}[$__rate_interval]))`).
+			LegendFormat("{{bucket}} {{handler}}").
+			RefId("A"),
+		).
+		Unit(units.RequestsPerSecond).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		OverrideByRegexp(".*", []dashboard.DynamicConfigValue{fixedColor("#FFEE58")}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	// ─────────────────────────────────────────────────────────────────────────────
+	// Latency row
+	// ─────────────────────────────────────────────────────────────────────────────
+	WithRow(
+		dashboard.NewRowBuilder("Latency"),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Request latency (quantiles)").
+		Description("Percentiles of the S3 requests latency for the selected buckets and handlers.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`histogram_quantile(0.5, sum by(le) (rate(http_request_duration_seconds_bucket{bucket=~"$bucket", handler=~"$handler"}[$__rate_interval])))`).
+			LegendFormat("p50").
+			RefId("A"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`histogram_quantile(0.90, sum by(le) (rate(http_request_duration_seconds_bucket{bucket=~"$bucket", handler=~"$handler"}[$__rate_interval])))`).
+			LegendFormat("p90").
+			RefId("B"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`histogram_quantile(0.99, sum by(le) (rate(http_request_duration_seconds_bucket{bucket=~"$bucket", handler=~"$handler"}[$__rate_interval])))`).
+			LegendFormat("p99").
+			RefId("C"),
+		).
+		Unit(units.Seconds).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Request latency p99 by handler").
+		Description("99th percentile of the S3 requests latency by handler. Compare it with the latency measured by the client to tell whether slow requests are caused by the client or the service.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`histogram_quantile(0.99, sum by(le, handler) (rate(http_request_duration_seconds_bucket{bucket=~"$bucket", handler=~"$handler"}[$__rate_interval])))`).
+			LegendFormat("{{handler}}").
+			RefId("A"),
+		).
+		Unit(units.Seconds).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("First byte latency p99 by handler").
+		Description("99th percentile of the time to the first byte of the response by handler. Shown only when the metric is exported for the bucket.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`histogram_quantile(0.99, sum by(le, handler) (rate(http_first_byte_duration_seconds_bucket{bucket=~"$bucket", handler=~"$handler"}[$__rate_interval])))`).
+			LegendFormat("{{handler}}").
+			RefId("A"),
+		).
+		Unit(units.Seconds).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	// ─────────────────────────────────────────────────────────────────────────────
+	// Latency heatmap row (repeated per handler)
+	// ─────────────────────────────────────────────────────────────────────────────
+	WithRow(
+		dashboard.NewRowBuilder("Latency heatmap for $handler").
+			Repeat("handler"),
+	).
+
+	WithPanel(heatmap.NewPanelBuilder().
+		Title("Request latency").
+		Description("Distribution of the $handler requests latency.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by(le) (increase(http_request_duration_seconds_bucket{bucket=~"$bucket", handler="$handler"}[$__rate_interval]))`).
+			LegendFormat("{{le}}").
+			Format(prometheus.PromQueryFormatHeatmap).
+			RefId("A"),
+		).
+		Calculate(false).
+		Color(heatmap.NewHeatmapColorOptionsBuilder().
+			Mode(heatmap.HeatmapColorModeScheme).
+			Scheme("Oranges").
+			Scale(heatmap.HeatmapColorScaleExponential).
+			Exponent(0.5),
+		).
+		YAxis(heatmap.NewYAxisConfigBuilder().
+			Unit(units.Seconds),
+		).
+		CellValues(heatmap.NewCellValuesBuilder().
+			Unit(units.Short),
+		).
+		Mode(common.TooltipDisplayModeSingle).
+		ShowYHistogram().
+		Height(8).
+		Span(12),
+	).
+
+	WithPanel(heatmap.NewPanelBuilder().
+		Title("First byte latency").
+		Description("Distribution of the time to the first byte of the $handler responses. Shown only when the metric is exported for the bucket.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by(le) (increase(http_first_byte_duration_seconds_bucket{bucket=~"$bucket", handler="$handler"}[$__rate_interval]))`).
+			LegendFormat("{{le}}").
+			Format(prometheus.PromQueryFormatHeatmap).
+			RefId("A"),
+		).
+		Calculate(false).
+		Color(heatmap.NewHeatmapColorOptionsBuilder().
+			Mode(heatmap.HeatmapColorModeScheme).
+			Scheme("Oranges").
+			Scale(heatmap.HeatmapColorScaleExponential).
+			Exponent(0.5),
+		).
+		YAxis(heatmap.NewYAxisConfigBuilder().
+			Unit(units.Seconds),
+		).
+		CellValues(heatmap.NewCellValuesBuilder().
+			Unit(units.Short),
+		).
+		Mode(common.TooltipDisplayModeSingle).
+		ShowYHistogram().
+		Height(8).
+		Span(12),
+	).
+
 	// ─────────────────────────────────────────────────────────────────────────────
 	// Objects statistics row
 	// ─────────────────────────────────────────────────────────────────────────────
@@ -295,6 +669,424 @@ var NebiusObjectStorage = dashboard.NewDashboardBuilder("Nebius Object Storage")
 		Span(8),
 	).
 
+	// ─────────────────────────────────────────────────────────────────────────────
+	// Incomplete multipart uploads row
+	// ─────────────────────────────────────────────────────────────────────────────
+	WithRow(
+		dashboard.NewRowBuilder("Incomplete multipart uploads"),
+	).
+
+	WithPanel(stat.NewPanelBuilder().
+		Title("Incomplete uploads space").
+		Description("Space used by the parts of multipart uploads that were neither completed nor aborted.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+objectStorageInflightSize(`bucket=~"$bucket"`)+`)`).
+			Instant().
+			RefId("A"),
+		).
+		Unit(units.BytesIEC).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(3),
+	).
+
+	WithPanel(stat.NewPanelBuilder().
+		Title("Persistent incomplete uploads space").
+		Description("Space used by incomplete multipart uploads during all of the last 7 days in buckets where it never shrank, most likely by abandoned uploads.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+objectStorageInflightPersistentSize(`bucket=~"$bucket"`)+`)`).
+			Instant().
+			RefId("A"),
+		).
+		Unit(units.BytesIEC).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(41, 156, 70)",
+				},
+				{
+					Value: New(float64(objectStorageAbandonedUploadsBytes)),
+					Color: "rgb(212, 74, 58)",
+				},
+			}),
+		).
+		Height(8).
+		Span(3),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Incomplete uploads space trend").
+		Description("Space used by incomplete multipart uploads over the last 30 days. Space that only grows points to clients that abandon uploads instead of aborting them.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageInflightSize(`bucket=~"$bucket"`)).
+			LegendFormat("{{bucket}}").
+			RefId("A"),
+		).
+		Unit(units.BytesIEC).
+		FillOpacity(5).
+		ShowPoints(common.VisibilityModeNever).
+		TimeFrom("30d").
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	WithPanel(table.NewPanelBuilder().
+		Title("Buckets with incomplete uploads").
+		Description("Buckets holding parts of incomplete multipart uploads. Persistent space is the least space used by the parts during the last 7 days in buckets where it never shrank, which is taken by uploads older than a week and most likely abandoned. Configure a lifecycle rule to abort incomplete multipart uploads to free it.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageInflightSize(`bucket=~"$bucket"`)+` > 0`).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Space"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageInflightPersistentSize(`bucket=~"$bucket"`)).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Persistent space"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum by(bucket) (max by(bucket, counter) (last_over_time(buckets_stat_quantity{bucket=~"$bucket", counter="inflight_parts"}[1m]))) > 0`).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Parts"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageInflightSize(`bucket=~"$bucket"`)+` / `+objectStorageBucketSize+` > 0`).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Share of bucket"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageInflightCost(`bucket=~"$bucket"`)+` > 0`).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Monthly cost"),
+		).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id:      "merge",
+			Options: map[string]any{},
+		}).
+		WithTransformation(organizeFields(
+			[]string{"Time"},
+			map[string]string{
+				"bucket":                  "Bucket",
+				"Value #Space":            "Space",
+				"Value #Persistent space": "Persistent space",
+				"Value #Parts":            "Parts",
+				"Value #Share of bucket":  "Share of bucket",
+				"Value #Monthly cost":     "Monthly cost",
+			},
+		)).
+		OverrideByRegexp("Space|Persistent space", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.BytesIEC},
+		}).
+		OverrideByName("Persistent space", []dashboard.DynamicConfigValue{
+			{Id: "custom.cellOptions", Value: map[string]string{"type": string(common.TableCellDisplayModeColorBackground)}},
+			{Id: "thresholds", Value: dashboard.ThresholdsConfig{
+				Mode: dashboard.ThresholdsModeAbsolute,
+				Steps: []dashboard.Threshold{
+					{Color: "transparent"},
+					{Value: New(float64(objectStorageAbandonedUploadsBytes)), Color: "rgb(212, 74, 58)"},
+				},
+			}},
+		}).
+		OverrideByName("Parts", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.Short},
+		}).
+		OverrideByName("Share of bucket", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.PercentUnit},
+		}).
+		OverrideByName("Monthly cost", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.Dollars},
+			{Id: "decimals", Value: 2},
+		}).
+		SortBy([]cog.Builder[common.TableSortByFieldState]{
+			common.NewTableSortByFieldStateBuilder().
+				DisplayName("Persistent space").
+				Desc(true),
+		}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(10),
+	).
+
+	// ─────────────────────────────────────────────────────────────────────────────
+	// Cost row
+	// ─────────────────────────────────────────────────────────────────────────────
+	WithRow(
+		dashboard.NewRowBuilder("Estimated cost"),
+	).
+
+	WithPanel(stat.NewPanelBuilder().
+		Title("Estimated monthly cost").
+		Description("Monthly cost of the selected buckets estimated from the current storage usage and the request and egress rates within the dashboard time range. Prices are set by the dashboard variables.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`(sum(`+objectStorageStorageCost()+`) OR on() vector(0)) + (sum(`+objectStorageRequestsCost("$__range")+`) OR on() vector(0)) + (sum(`+objectStorageEgressCost("$__range")+`) OR on() vector(0))`).
+			Instant().
+			RefId("A"),
+		).
+		Unit(units.Dollars).
+		Decimals(2).
+		ColorMode(common.BigValueColorModeNone).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(4),
+	).
+
+	WithPanel(timeseries.NewPanelBuilder().
+		Title("Estimated monthly cost over time").
+		Description("Monthly cost of the selected buckets extrapolated from the storage usage and the request and egress rates at each point in time.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+objectStorageStorageCost()+`)`).
+			LegendFormat("Storage").
+			RefId("A"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+objectStorageRequestsCost("$__rate_interval")+`)`).
+			LegendFormat("Requests").
+			RefId("B"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+objectStorageEgressCost("$__rate_interval")+`)`).
+			LegendFormat("Egress").
+			RefId("C"),
+		).
+		Unit(units.Dollars).
+		FillOpacity(70).
+		Stacking(common.NewStackingConfigBuilder().
+			Mode(common.StackingModeNormal),
+		).
+		ShowPoints(common.VisibilityModeNever).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8),
+	).
+
+	WithPanel(table.NewPanelBuilder().
+		Title("Estimated monthly cost per bucket").
+		Description("Monthly cost of each selected bucket split into storage, requests and egress.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageStorageCost()).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Storage"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageRequestsCost("$__range")).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Requests"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageEgressCost("$__range")).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Egress"),
+		).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id:      "merge",
+			Options: map[string]any{},
+		}).
+		WithTransformation(organizeFields(
+			[]string{"Time"},
+			map[string]string{
+				"bucket":          "Bucket",
+				"Value #Storage":  "Storage",
+				"Value #Requests": "Requests",
+				"Value #Egress":   "Egress",
+			},
+		)).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id: "calculateField",
+			Options: map[string]any{
+				"mode":  "reduceRow",
+				"alias": "Total",
+				"reduce": map[string]any{
+					"reducer": "sum",
+					"include": []string{"Storage", "Requests", "Egress"},
+				},
+			},
+		}).
+		Unit(units.Dollars).
+		Decimals(2).
+		SortBy([]cog.Builder[common.TableSortByFieldState]{
+			common.NewTableSortByFieldStateBuilder().
+				DisplayName("Total").
+				Desc(true),
+		}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(12),
+	).
+
+	Annotation(grafanaAlertsAnnotation()).
+	Annotation(firingAlertsAnnotation(`bucket=~"$bucket"`)).
+
 	Time("now-24h", "now").
 	Refresh("1m").
-	Readonly()
\ No newline at end of file
+	Readonly()
+
+// objectStoragePrices are the default prices in USD used to estimate the cost.
+// They are exposed as dashboard variables to be adjusted to the actual prices.
+var objectStoragePrices = struct {
+	Standard           float64 // per GiB-month
+	EnhancedThroughput float64 // per GiB-month
+	ReadRequests       float64 // per 1000 requests
+	MutateRequests     float64 // per 1000 requests
+	Egress             float64 // per GiB
+}{
+	Standard:           0.0147,
+	EnhancedThroughput: 0.1,
+	ReadRequests:       0.0004,
+	MutateRequests:     0.005,
+	Egress:             0,
+}
+
+const secondsPerMonth = 30 * 24 * 3600
+
+func objectStorageStorageCost() string {
+	return `sum by(bucket) (max by(bucket, counter, storage_class) (last_over_time(buckets_stat_size{bucket=~"$bucket", storage_class="STANDARD"}[1m])) / 1073741824 * $price_standard OR max by(bucket, counter, storage_class) (last_over_time(buckets_stat_size{bucket=~"$bucket", storage_class="ENHANCED_THROUGHPUT"}[1m])) / 1073741824 * $price_enhanced_throughput)`
+}
+
+func objectStorageRequestsCost(window string) string {
+	return fmt.Sprintf(`sum by(bucket) (rate(request_rate{bucket=~"$bucket", operation_type="read"}[%[1]s]) * %[2]d / 1000 * $price_read_requests OR rate(request_rate{bucket=~"$bucket", operation_type="mutate"}[%[1]s]) * %[2]d / 1000 * $price_mutate_requests)`, window, secondsPerMonth)
+}
+
+func objectStorageEgressCost(window string) string {
+	return fmt.Sprintf(`sum by(bucket) (rate(http_bytes_sent{bucket=~"$bucket"}[%s])) * %d / 1073741824 * $price_egress`, window, secondsPerMonth)
+}
+
+// S3 errors are classified by the status code, except SlowDown, which is
+// returned with 503 but means that the client is throttled.
+const (
+	objectStorageClientErrors    = `http_code=~"4.."`
+	objectStorageServerErrors    = `http_code=~"5..", api_error_code!="SlowDown"`
+	objectStorageThrottledErrors = `api_error_code="SlowDown"`
+)
+
+const (
+	objectStorageBucketSize     = `sum by(bucket) (max by(bucket, counter) (last_over_time(buckets_stat_size{bucket=~"$bucket"}[1m])))`
+	objectStorageBucketGrowth   = `delta(` + objectStorageBucketSize + `[$__range:5m])`
+	objectStorageBucketRequests = `sum by(bucket) (rate(request_rate{bucket=~"$bucket"}[$__range]))`
+	objectStorageBucketErrors   = `sum by(bucket) (rate(http_errors_total{bucket=~"$bucket"}[$__range])) / ` + objectStorageBucketRequests
+	objectStorageBucketEgress   = `sum by(bucket) (rate(http_bytes_sent{bucket=~"$bucket"}[$__range]))`
+)
+
+// objectStorageTopBuckets returns a table of the Top N buckets by the query,
+// sorted by its column. Bucket names link to the dashboard filtered by the
+// bucket.
+func objectStorageTopBuckets(title, description, query, column string) *table.PanelBuilder {
+	top := ` and on(bucket) topk($top_n, ` + query + `)`
+	return table.NewPanelBuilder().
+		Title(title).
+		Description(description).
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageBucketSize+top).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Size"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageBucketGrowth+top).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Growth"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageBucketRequests+top).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Requests"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`(`+objectStorageBucketErrors+`)`+top).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Error rate"),
+		).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(objectStorageBucketEgress+top).
+			Format(prometheus.PromQueryFormatTable).
+			Instant().
+			RefId("Egress"),
+		).
+		WithTransformation(dashboard.DataTransformerConfig{
+			Id:      "merge",
+			Options: map[string]any{},
+		}).
+		WithTransformation(organizeFields(
+			[]string{"Time"},
+			map[string]string{
+				"bucket":            "Bucket",
+				"Value #Size":       "Size",
+				"Value #Growth":     "Growth",
+				"Value #Requests":   "Requests",
+				"Value #Error rate": "Error rate",
+				"Value #Egress":     "Egress",
+			},
+		)).
+		OverrideByName("Bucket", []dashboard.DynamicConfigValue{
+			{Id: "links", Value: []map[string]string{
+				{
+					"title": "Show bucket",
+					"url":   "/d/nebius-object-storage?var-datasource=${datasource}&var-bucket=${__value.raw}&${__url_time_range}",
+				},
+			}},
+		}).
+		OverrideByRegexp("Size|Growth", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.BytesIEC},
+		}).
+		OverrideByName("Requests", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.RequestsPerSecond},
+		}).
+		OverrideByName("Error rate", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.PercentUnit},
+		}).
+		OverrideByName("Egress", []dashboard.DynamicConfigValue{
+			{Id: "unit", Value: units.BytesPerSecondIEC},
+		}).
+		SortBy([]cog.Builder[common.TableSortByFieldState]{
+			common.NewTableSortByFieldStateBuilder().
+				DisplayName(column).
+				Desc(true),
+		}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(8)
+}
+
+// objectStorageAbandonedUploadsBytes is the persistent space of incomplete
+// multipart uploads in a bucket that is reported as wasted.
+const objectStorageAbandonedUploadsBytes = 10 * 1073741824
+
+func objectStorageInflightSize(selector string) string {
+	return fmt.Sprintf(`sum by(bucket) (max by(bucket, counter) (last_over_time(buckets_stat_size{%s, counter="inflight_parts"}[1m])))`, selector)
+}
+
+// objectStorageInflightCost returns the monthly cost of the space used by
+// incomplete multipart uploads, priced by the storage class of the parts.
+func objectStorageInflightCost(selector string) string {
+	return fmt.Sprintf(`sum by(bucket) (max by(bucket, counter, storage_class) (last_over_time(buckets_stat_size{%[1]s, counter="inflight_parts", storage_class="STANDARD"}[1m])) / 1073741824 * $price_standard OR max by(bucket, counter, storage_class) (last_over_time(buckets_stat_size{%[1]s, counter="inflight_parts", storage_class="ENHANCED_THROUGHPUT"}[1m])) / 1073741824 * $price_enhanced_throughput)`, selector)
+}
+
+// objectStorageInflightPersistentSize returns the least space used by
+// incomplete multipart uploads during the last 7 days in buckets where that
+// space never shrank, which is held by uploads that were started more than a
+// week ago and neither completed nor aborted since. Buckets where uploads
+// complete or get aborted are left out, as their least space may be taken by
+// a stream of short uploads.
+func objectStorageInflightPersistentSize(selector string) string {
+	size := objectStorageInflightSize(selector)
+	return `min_over_time(` + size + `[7d:1h]) > 0 and on(bucket) resets(` + size + `[7d:1h]) == 0`
+}
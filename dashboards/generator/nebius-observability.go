@@ -1,20 +1,58 @@
 package main
 
 import (
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
+	"github.com/grafana/grafana-foundation-sdk/go/common"
 	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/gauge"
 	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
 	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
 )
 
+// Series cardinality metrics of Nebius Monitoring and the type of the limit of
+// active series in requests_limits. The names are assumed from the naming of
+// requests_total, samples_total and the request limit types, and are not
+// documented yet, so the cardinality panels show no data rather than zero when
+// the metrics are missing.
+const (
+	monitoringActiveSeries  = "active_series"
+	monitoringSeriesCreated = "series_created_total"
+	monitoringSeriesRemoved = "series_removed_total"
+	monitoringSeriesLimit   = `requests_limits{type="monitoring.active_series"}`
+)
+
 var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Platform").
 	Uid("nebius-observability").
 	Tags([]string{"Nebius", "Observability Platform"}).
 	Refresh("1m").
 	Time("now-1h", "now").
+	Annotation(grafanaAlertsAnnotation()).
+	Annotation(quotaExceededAnnotation()).
+	Annotation(firingAlertsAnnotation(`alert_group=~"nebius-.*"`)).
 	Timezone("browser").
 	Readonly().
 	Tooltip(dashboard.DashboardCursorSyncCrosshair).
 	WithVariable(DatasourceVar).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("logs_group_by").
+			Label("Logs by").
+			Description("Label to break down the logging usage by.").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New("label_names(logging_ingest_logs_bytes_total)"),
+			}).
+			Regex(`/^(?!__name__$).+$/`).
+			AllowCustomValue(false).
+			Current(dashboard.VariableOption{
+				Text: dashboard.StringOrArrayOfString{
+					String: New("bucket"),
+				},
+				Value: dashboard.StringOrArrayOfString{
+					String: New("bucket"),
+				},
+			}),
+	).
 	Description("Unified overview of Nebius Observability usage. https://docs.nebius.com/observability").
 	Link(dashboard.NewDashboardLinkBuilder("Docs").
 		Type(dashboard.DashboardLinkTypeLink).
@@ -161,9 +199,149 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 				},
 			),
 	).
+	WithPanel(
+		timeseries.NewPanelBuilder().
+			Title("Active series").
+			Datasource(DatasourceRef).
+			Description("Number of active time series, compared to the limit").
+			Unit("short").
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 25}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum(`+monitoringActiveSeries+`{})`).
+					LegendFormat("Series").
+					RefId("Active series").
+					Range(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(monitoringSeriesLimit).
+					LegendFormat("Series limit").
+					RefId("Series limit").
+					Range(),
+			).
+			OverrideByQuery("Series limit", []dashboard.DynamicConfigValue{
+				fixedColor("dark-red"),
+				{Id: "custom.fillOpacity", Value: 0},
+				{Id: "custom.hideFrom", Value: map[string]bool{"legend": true, "tooltip": false, "viz": false}},
+				{Id: "custom.drawStyle", Value: "line"},
+				{Id: "custom.showPoints", Value: "never"},
+				{Id: "custom.lineWidth", Value: 1},
+			}).
+			WithOverride(dashboard.MatcherConfig{Id: "byRegexp", Options: ".*"},
+				[]dashboard.DynamicConfigValue{{Id: "custom.drawStyle", Value: "line"}, {Id: "custom.showPoints", Value: "never"}, {Id: "custom.lineWidth", Value: 1}},
+			),
+	).
+	WithPanel(
+		timeseries.NewPanelBuilder().
+			Title("Series churn").
+			Datasource(DatasourceRef).
+			Description("Number of time series created and removed per second. High churn increases the active series count even if the number of series written at once is stable").
+			Unit("short").
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 25}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum(rate(`+monitoringSeriesCreated+`{}[$__rate_interval]))`).
+					LegendFormat("Created").
+					RefId("Created series").
+					Range(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum(rate(`+monitoringSeriesRemoved+`{}[$__rate_interval]))`).
+					LegendFormat("Removed").
+					RefId("Removed series").
+					Range(),
+			).
+			WithOverride(dashboard.MatcherConfig{Id: "byRegexp", Options: ".*"},
+				[]dashboard.DynamicConfigValue{{Id: "custom.drawStyle", Value: "line"}, {Id: "custom.showPoints", Value: "never"}, {Id: "custom.lineWidth", Value: 1}},
+			),
+	).
+	WithPanel(
+		gauge.NewPanelBuilder().
+			Title("Series limit usage").
+			Datasource(DatasourceRef).
+			Description("Number of active time series compared to the limit. Writes of new series are throttled when the limit is reached").
+			Unit("short").
+			GridPos(dashboard.GridPos{H: 8, W: 8, X: 0, Y: 33}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum(` + monitoringActiveSeries + `{})`).
+					LegendFormat("Series").
+					RefId("Series").
+					Instant(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(monitoringSeriesLimit).
+					LegendFormat("Limit").
+					RefId("Limit").
+					Instant(),
+			).
+			WithTransformation(maxFromQuery("Limit")).
+			Min(0).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Mode(dashboard.ThresholdsModePercentage).
+				Steps([]dashboard.Threshold{
+					{Color: "green"},
+					{Value: New(80.0), Color: "orange"},
+					{Value: New(95.0), Color: "dark-red"},
+				}),
+			),
+	).
+	WithPanel(
+		table.NewPanelBuilder().
+			Title("Top metrics by cardinality").
+			Datasource(DatasourceRef).
+			Description("Metric names with the most active time series, and their share of the series limit").
+			GridPos(dashboard.GridPos{H: 8, W: 16, X: 8, Y: 33}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`topk(20, sum by(metric_name) (`+monitoringActiveSeries+`{}))`).
+					Format(prometheus.PromQueryFormatTable).
+					RefId("Series").
+					Instant(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum by(metric_name) (`+monitoringActiveSeries+`{}) / on() group_left() `+monitoringSeriesLimit).
+					Format(prometheus.PromQueryFormatTable).
+					RefId("Limit usage").
+					Instant(),
+			).
+			WithTransformation(dashboard.DataTransformerConfig{
+				Id:      "merge",
+				Options: map[string]any{},
+			}).
+			WithTransformation(dashboard.DataTransformerConfig{
+				Id: "filterByValue",
+				Options: map[string]any{
+					"type":  "include",
+					"match": "any",
+					"filters": []map[string]any{
+						{"fieldName": "Value #Series", "config": map[string]any{"id": "isNotNull"}},
+					},
+				},
+			}).
+			WithTransformation(organizeFields(
+				[]string{"Time"},
+				map[string]string{
+					"metric_name":        "Metric",
+					"Value #Series":      "Active series",
+					"Value #Limit usage": "Limit usage",
+				},
+			)).
+			OverrideByName("Active series", []dashboard.DynamicConfigValue{{Id: "unit", Value: "short"}}).
+			OverrideByName("Limit usage", []dashboard.DynamicConfigValue{{Id: "unit", Value: "percentunit"}}).
+			SortBy([]cog.Builder[common.TableSortByFieldState]{
+				common.NewTableSortByFieldStateBuilder().
+					DisplayName("Active series").
+					Desc(true),
+			}),
+	).
 	WithRow(
 		dashboard.NewRowBuilder("Logging").
-			GridPos(dashboard.GridPos{H: 1, W: 24, X: 0, Y: 25}).
+			GridPos(dashboard.GridPos{H: 1, W: 24, X: 0, Y: 41}).
 			Id(200),
 	).
 	WithPanel(
@@ -172,7 +350,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Number of successful log ingestion requests per second").
 			Unit("reqps").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 26}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 42}).
 			WithTarget(
 				prometheus.NewDataqueryBuilder().
 					Expr(`sum(rate(logging_ingest_requests_total{status="ok"}[$__rate_interval])) OR on() vector(0)`).
@@ -194,7 +372,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Number of failed log ingestion requests per second, by status code").
 			Unit("reqps").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 26}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 42}).
 			WithTarget(
 				prometheus.NewDataqueryBuilder().
 					Expr(`sum by(status) (rate(logging_ingest_requests_total{status!="ok"}[$__rate_interval])) or on() vector(0)`).
@@ -221,7 +399,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Number of successful log read/query requests per second").
 			Unit("reqps").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 34}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 50}).
 			WithTarget(
 				prometheus.NewDataqueryBuilder().
 					Expr(`sum(rate(logging_read_requests_total{status="ok"}[$__rate_interval])) OR on() vector(0)`).
@@ -243,7 +421,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Number of failed log read/query requests per second, by status code").
 			Unit("reqps").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 34}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 50}).
 			WithTarget(
 				prometheus.NewDataqueryBuilder().
 					Expr(`sum by(status) (rate(logging_read_requests_total{status!="ok"}[$__rate_interval])) or on() vector(0)`).
@@ -270,7 +448,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Number of log lines ingested per second").
 			Unit("short").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 42}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 58}).
 			WithTarget(
 				prometheus.NewDataqueryBuilder().
 					Expr(`sum(rate(logging_ingest_logs_total{}[$__rate_interval])) OR on() vector(0)`).
@@ -288,7 +466,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Volume of log data ingested per second in bytes").
 			Unit("binBps").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 42}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 58}).
 			WithTarget(
 				prometheus.NewDataqueryBuilder().
 					Expr(`sum(rate(logging_ingest_logs_bytes_total{}[$__rate_interval])) OR on() vector(0)`).
@@ -306,7 +484,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Request processing time quantiles for log ingestion operations").
 			Unit("s").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 50}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 66}).
 			WithTarget(prometheus.NewDataqueryBuilder().Expr(`histogram_quantile(0.5, sum by(le)(rate(logging_ingest_duration_seconds_bucket{}[$__rate_interval])))`).LegendFormat("p50").RefId("A").Range()).
 			WithTarget(prometheus.NewDataqueryBuilder().Expr(`histogram_quantile(0.75, sum by(le)(rate(logging_ingest_duration_seconds_bucket{}[$__rate_interval])))`).LegendFormat("p75").RefId("B").Range()).
 			WithTarget(prometheus.NewDataqueryBuilder().Expr(`histogram_quantile(0.90, sum by(le)(rate(logging_ingest_duration_seconds_bucket{}[$__rate_interval])))`).LegendFormat("p90").RefId("C").Range()).
@@ -322,7 +500,7 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			Datasource(DatasourceRef).
 			Description("Time delay between receiving a log and saving it to storage").
 			Unit("s").
-			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 50}).
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 66}).
 			WithTarget(prometheus.NewDataqueryBuilder().Expr(`histogram_quantile(0.5, sum by(le)(rate(logging_storage_save_lag_seconds_bucket{}[$__rate_interval])))`).LegendFormat("p50").RefId("A").Range()).
 			WithTarget(prometheus.NewDataqueryBuilder().Expr(`histogram_quantile(0.75, sum by(le)(rate(logging_storage_save_lag_seconds_bucket{}[$__rate_interval])))`).LegendFormat("p75").RefId("B").Range()).
 			WithTarget(prometheus.NewDataqueryBuilder().Expr(`histogram_quantile(0.90, sum by(le)(rate(logging_storage_save_lag_seconds_bucket{}[$__rate_interval])))`).LegendFormat("p90").RefId("C").Range()).
@@ -331,6 +509,160 @@ var NebiusObservability = dashboard.NewDashboardBuilder("Nebius Observability Pl
 			WithOverride(dashboard.MatcherConfig{Id: "byRegexp", Options: ".*"},
 				[]dashboard.DynamicConfigValue{{Id: "custom.drawStyle", Value: "line"}, {Id: "custom.showPoints", Value: "never"}, {Id: "custom.lineWidth", Value: 1}},
 			),
+	).
+	WithPanel(
+		timeseries.NewPanelBuilder().
+			Title("Write bytes by $logs_group_by").
+			Datasource(DatasourceRef).
+			Description("Volume of log data ingested per second in bytes, by the selected label").
+			Unit("binBps").
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 74}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum by($logs_group_by) (rate(logging_ingest_logs_bytes_total{}[$__rate_interval]))`).
+					LegendFormat("{{$logs_group_by}}").
+					RefId("A").
+					Range(),
+			).
+			WithOverride(dashboard.MatcherConfig{Id: "byRegexp", Options: ".*"},
+				[]dashboard.DynamicConfigValue{{Id: "custom.drawStyle", Value: "line"}, {Id: "custom.showPoints", Value: "never"}, {Id: "custom.lineWidth", Value: 1}},
+			),
+	).
+	WithPanel(
+		timeseries.NewPanelBuilder().
+			Title("Written lines rate by $logs_group_by").
+			Datasource(DatasourceRef).
+			Description("Number of log lines ingested per second, by the selected label").
+			Unit("short").
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 74}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum by($logs_group_by) (rate(logging_ingest_logs_total{}[$__rate_interval]))`).
+					LegendFormat("{{$logs_group_by}}").
+					RefId("A").
+					Range(),
+			).
+			WithOverride(dashboard.MatcherConfig{Id: "byRegexp", Options: ".*"},
+				[]dashboard.DynamicConfigValue{{Id: "custom.drawStyle", Value: "line"}, {Id: "custom.showPoints", Value: "never"}, {Id: "custom.lineWidth", Value: 1}},
+			),
+	).
+	WithPanel(
+		timeseries.NewPanelBuilder().
+			Title("Write bytes quota").
+			Datasource(DatasourceRef).
+			Description("Volume of log data ingested per second in bytes, compared to the quota").
+			Unit("binBps").
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 0, Y: 82}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum(rate(logging_ingest_logs_bytes_total{}[$__rate_interval])) or on() vector(0)`).
+					LegendFormat("Bytes").
+					RefId("Bytes rate").
+					Range(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`requests_limits{type="logging.write.throughput.bytes"}`).
+					LegendFormat("Bytes limit").
+					RefId("Bytes limit").
+					Range(),
+			).
+			OverrideByQuery("Bytes limit", []dashboard.DynamicConfigValue{
+				fixedColor("dark-red"),
+				{Id: "custom.fillOpacity", Value: 0},
+				{Id: "custom.hideFrom", Value: map[string]bool{"legend": true, "tooltip": false, "viz": false}},
+				{Id: "custom.drawStyle", Value: "line"},
+				{Id: "custom.showPoints", Value: "never"},
+				{Id: "custom.lineWidth", Value: 1},
+			}).
+			WithOverride(dashboard.MatcherConfig{Id: "byRegexp", Options: ".*"},
+				[]dashboard.DynamicConfigValue{{Id: "custom.drawStyle", Value: "line"}, {Id: "custom.showPoints", Value: "never"}, {Id: "custom.lineWidth", Value: 1}},
+			),
+	).
+	WithPanel(
+		timeseries.NewPanelBuilder().
+			Title("Quota exceeded by $logs_group_by").
+			Datasource(DatasourceRef).
+			Description("Number of log ingestion requests per second rejected by the quota, by the selected label").
+			Unit("reqps").
+			GridPos(dashboard.GridPos{H: 8, W: 12, X: 12, Y: 82}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum by($logs_group_by) (rate(logging_ingest_requests_total{status="quota_exceeded"}[$__rate_interval]))`).
+					LegendFormat("{{$logs_group_by}}").
+					RefId("A").
+					Range(),
+			).
+			WithOverride(dashboard.MatcherConfig{Id: "byRegexp", Options: ".*"},
+				[]dashboard.DynamicConfigValue{{Id: "custom.drawStyle", Value: "line"}, {Id: "custom.showPoints", Value: "never"}, {Id: "custom.lineWidth", Value: 1}},
+			),
+	).
+	WithPanel(
+		table.NewPanelBuilder().
+			Title("Top log producers").
+			Datasource(DatasourceRef).
+			Description("Values of the selected label that ingested the most log data within the dashboard time range, with the requests rejected by the quota").
+			GridPos(dashboard.GridPos{H: 8, W: 24, X: 0, Y: 90}).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`topk(10, sum by($logs_group_by) (increase(logging_ingest_logs_bytes_total{}[$__range])))`).
+					Format(prometheus.PromQueryFormatTable).
+					RefId("Bytes").
+					Instant(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum by($logs_group_by) (increase(logging_ingest_logs_bytes_total{}[$__range])) / on() group_left() sum(increase(logging_ingest_logs_bytes_total{}[$__range]))`).
+					Format(prometheus.PromQueryFormatTable).
+					RefId("Share").
+					Instant(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum by($logs_group_by) (increase(logging_ingest_logs_total{}[$__range]))`).
+					Format(prometheus.PromQueryFormatTable).
+					RefId("Lines").
+					Instant(),
+			).
+			WithTarget(
+				prometheus.NewDataqueryBuilder().
+					Expr(`sum by($logs_group_by) (increase(logging_ingest_requests_total{status="quota_exceeded"}[$__range]))`).
+					Format(prometheus.PromQueryFormatTable).
+					RefId("Quota exceeded").
+					Instant(),
+			).
+			WithTransformation(dashboard.DataTransformerConfig{
+				Id:      "merge",
+				Options: map[string]any{},
+			}).
+			WithTransformation(dashboard.DataTransformerConfig{
+				Id: "filterByValue",
+				Options: map[string]any{
+					"type":  "include",
+					"match": "any",
+					"filters": []map[string]any{
+						{"fieldName": "Value #Bytes", "config": map[string]any{"id": "isNotNull"}},
+					},
+				},
+			}).
+			WithTransformation(organizeFields(
+				[]string{"Time"},
+				map[string]string{
+					"Value #Bytes":          "Bytes",
+					"Value #Share":          "Share",
+					"Value #Lines":          "Lines",
+					"Value #Quota exceeded": "Quota exceeded requests",
+				},
+			)).
+			OverrideByName("Bytes", []dashboard.DynamicConfigValue{{Id: "unit", Value: "bytes"}}).
+			OverrideByName("Share", []dashboard.DynamicConfigValue{{Id: "unit", Value: "percentunit"}}).
+			OverrideByName("Lines", []dashboard.DynamicConfigValue{{Id: "unit", Value: "short"}}).
+			OverrideByName("Quota exceeded requests", []dashboard.DynamicConfigValue{{Id: "unit", Value: "short"}, {Id: "decimals", Value: 0}}).
+			SortBy([]cog.Builder[common.TableSortByFieldState]{
+				common.NewTableSortByFieldStateBuilder().
+					DisplayName("Bytes").
+					Desc(true),
+			}),
 	)
 
 func fixedColor(col string) dashboard.DynamicConfigValue {
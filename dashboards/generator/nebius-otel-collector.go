@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-foundation-sdk/go/common"
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/stat"
+	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
+	"github.com/grafana/grafana-foundation-sdk/go/units"
+)
+
+// Internal metrics of the collectors deployed by the otel-collector chart,
+// which exports them over OTLP with the node name as service.instance.id, the
+// resource attribute that becomes the instance label. Every collector of the
+// daemonset runs on its own node.
+const otelcolSelector = `instance=~"$node"`
+
+var NebiusOtelCollector = dashboard.NewDashboardBuilder("Nebius Kubernetes Log Collector").
+	Uid("nebius-otel-collector").
+	Description("Dashboard to visualize the health of the log pipeline of the OpenTelemetry Collector that ships Kubernetes logs to Nebius Logging.").
+	Tags([]string{"Nebius", "Logging", "OpenTelemetry"}).
+	Link(dashboard.NewDashboardLinkBuilder("Docs").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("https://docs.nebius.com/observability").
+		TargetBlank(true).
+		Icon("doc"),
+	).
+	Link(dashboard.NewDashboardLinkBuilder("GitHub").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("https://github.com/nebius/observability").
+		TargetBlank(true).
+		Icon("external link"),
+	).
+	Link(dashboard.NewDashboardLinkBuilder("Logs").
+		Type(dashboard.DashboardLinkTypeLink).
+		Url("/d/nebius-logs?${__url_time_range}").
+		Icon("dashboard"),
+	).
+	WithVariable(
+		DatasourceVar,
+	).
+	WithVariable(
+		dashboard.NewQueryVariableBuilder("node").
+			Description("Kubernetes nodes the collector runs on.").
+			Datasource(DatasourceRef).
+			Query(dashboard.StringOrMap{
+				String: New("label_values(otelcol_receiver_accepted_log_records_total, instance)"),
+			}).
+			Multi(true).
+			IncludeAll(true).
+			AllValue(".*").
+			AllowCustomValue(false),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Accepted log records").
+		Description("Number of log records per second read by the receivers.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(rate(otelcol_receiver_accepted_log_records_total{`+otelcolSelector+`}[$__rate_interval]))`).
+			Range(),
+		).
+		Unit(units.Short).
+		Decimals(1).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(41, 156, 70)",
+				},
+			}),
+		).
+		Height(4).
+		Span(6),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Dropped log records").
+		Description("Number of log records per second refused by the receivers or failed to be sent or enqueued by the exporters.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(`+otelcolDroppedLogRecords(otelcolSelector, "$__rate_interval")+`) or vector(0)`).
+			Range(),
+		).
+		Unit(units.Short).
+		Decimals(1).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(41, 156, 70)",
+				},
+				{
+					Value: New(0.001),
+					Color: "rgb(212, 74, 58)",
+				},
+			}),
+		).
+		Height(4).
+		Span(6),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Export success ratio").
+		Description("Share of the log records that the exporters sent successfully.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(rate(otelcol_exporter_sent_log_records_total{`+otelcolSelector+`}[$__rate_interval])) / (sum(rate(otelcol_exporter_sent_log_records_total{`+otelcolSelector+`}[$__rate_interval])) + sum(rate(otelcol_exporter_send_failed_log_records_total{`+otelcolSelector+`}[$__rate_interval])))`).
+			Range(),
+		).
+		Unit(units.PercentUnit).
+		Decimals(2).
+		Mappings([]dashboard.ValueMapping{
+			{
+				SpecialValueMap: &dashboard.SpecialValueMap{
+					Type: dashboard.MappingTypeSpecialValue,
+					Options: dashboard.DashboardSpecialValueMapOptions{
+						Match: dashboard.SpecialValueMatchNull,
+						Result: dashboard.ValueMappingResult{
+							Text: New("N/A"),
+						},
+					},
+				},
+			},
+		}).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(212, 74, 58)",
+				},
+				{
+					Value: New(0.99),
+					Color: "rgb(237, 129, 40)",
+				},
+				{
+					Value: New(0.999),
+					Color: "rgb(41, 156, 70)",
+				},
+			}),
+		).
+		Height(4).
+		Span(6),
+	).
+	WithPanel(stat.NewPanelBuilder().
+		Title("Max queue usage").
+		Description("Highest usage of the sending queue across the exporters. Log records are dropped when the queue is full.").
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`max(otelcol_exporter_queue_size{`+otelcolSelector+`} / otelcol_exporter_queue_capacity{`+otelcolSelector+`})`).
+			Range(),
+		).
+		Unit(units.PercentUnit).
+		Decimals(1).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "rgb(41, 156, 70)",
+				},
+				{
+					Value: New(0.5),
+					Color: "rgb(237, 129, 40)",
+				},
+				{
+					Value: New(0.8),
+					Color: "rgb(212, 74, 58)",
+				},
+			}),
+		).
+		Height(4).
+		Span(6),
+	).
+	WithRow(dashboard.NewRowBuilder("Receivers")).
+	WithPanel(otelcolPanel("Accepted log records",
+		"Number of log records per second read by the receivers, by node.",
+		`sum by(instance) (rate(otelcol_receiver_accepted_log_records_total{`+otelcolSelector+`}[$__rate_interval]))`,
+		"{{instance}}",
+	)).
+	WithPanel(otelcolPanel("Refused log records",
+		"Number of log records per second refused by the receivers, by node. Records are refused when the next component of the pipeline returns an error, e.g. the memory limiter.",
+		`sum by(instance) (rate(otelcol_receiver_refused_log_records_total{`+otelcolSelector+`}[$__rate_interval]))`,
+		"{{instance}}",
+	)).
+	WithRow(dashboard.NewRowBuilder("Exporters")).
+	WithPanel(otelcolPanel("Sent log records",
+		"Number of log records per second sent to Nebius Logging, by node.",
+		`sum by(instance) (rate(otelcol_exporter_sent_log_records_total{`+otelcolSelector+`}[$__rate_interval]))`,
+		"{{instance}}",
+	)).
+	WithPanel(otelcolPanel("Failed log records",
+		"Number of log records per second that the exporters failed to send after all retries, or to enqueue because the sending queue was full, by node. These log records are lost.",
+		otelcolSumByNode(
+			`sum by(instance) (rate(otelcol_exporter_send_failed_log_records_total{`+otelcolSelector+`}[$__rate_interval]))`,
+			`sum by(instance) (rate(otelcol_exporter_enqueue_failed_log_records_total{`+otelcolSelector+`}[$__rate_interval]))`,
+		),
+		"{{instance}}",
+	)).
+	WithPanel(otelcolPanel("Queue usage",
+		"Number of batches in the sending queue as a percentage of its capacity, by node. A growing queue means that the exporter cannot keep up with the ingested logs.",
+		`max by(instance) (otelcol_exporter_queue_size{`+otelcolSelector+`} / otelcol_exporter_queue_capacity{`+otelcolSelector+`})`,
+		"{{instance}}",
+	).
+		Unit(units.PercentUnit).
+		Min(0).
+		Max(1).
+		Thresholds(dashboard.NewThresholdsConfigBuilder().
+			Steps([]dashboard.Threshold{
+				{
+					Color: "transparent",
+				},
+				{
+					Value: New(0.8),
+					Color: "rgb(212, 74, 58)",
+				},
+			}),
+		).
+		ThresholdsStyle(common.NewGraphThresholdsStyleConfigBuilder().
+			Mode(common.GraphThresholdsStyleModeLine),
+		),
+	).
+	WithPanel(otelcolPanel("Queue size",
+		"Number of batches in the sending queue, by node.",
+		`sum by(instance) (otelcol_exporter_queue_size{`+otelcolSelector+`})`,
+		"{{instance}}",
+	)).
+	WithRow(dashboard.NewRowBuilder("Batch processor")).
+	WithPanel(otelcolPanel("Batch send size (p50/p99)",
+		"Number of log records in the batches sent by the batch processor. Batches are sent when send_batch_size is reached or on timeout.",
+		`histogram_quantile(0.5, sum by(le) (rate(otelcol_processor_batch_batch_send_size_bucket{`+otelcolSelector+`}[$__rate_interval])))`,
+		"p50",
+	).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`histogram_quantile(0.99, sum by(le) (rate(otelcol_processor_batch_batch_send_size_bucket{`+otelcolSelector+`}[$__rate_interval])))`).
+			LegendFormat("p99").
+			Range(),
+		),
+	).
+	WithPanel(otelcolPanel("Batch send triggers",
+		"Number of batches sent per second because send_batch_size was reached or on timeout. Mostly timeout triggers mean that the batch size is larger than the log volume needs.",
+		`sum(rate(otelcol_processor_batch_batch_size_trigger_send_total{`+otelcolSelector+`}[$__rate_interval]))`,
+		"Batch size",
+	).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(`sum(rate(otelcol_processor_batch_timeout_trigger_send_total{`+otelcolSelector+`}[$__rate_interval]))`).
+			LegendFormat("Timeout").
+			Range(),
+		),
+	).
+	WithRow(dashboard.NewRowBuilder("Resources")).
+	WithPanel(otelcolPanel("Memory usage",
+		"Resident memory of the collector, by node.",
+		`max by(instance) (otelcol_process_memory_rss_bytes{`+otelcolSelector+`})`,
+		"{{instance}}",
+	).
+		Unit(units.BytesIEC),
+	).
+	WithPanel(otelcolPanel("CPU usage",
+		"CPU time used by the collector per second, by node.",
+		`sum by(instance) (rate(otelcol_process_cpu_seconds_total{`+otelcolSelector+`}[$__rate_interval]))`,
+		"{{instance}}",
+	)).
+	Annotation(grafanaAlertsAnnotation()).
+	Annotation(firingAlertsAnnotation(`instance=~"$node"`)).
+	Time("now-6h", "now").
+	Refresh("1m").
+	Readonly()
+
+func otelcolPanel(title, description, query, legend string) *timeseries.PanelBuilder {
+	return timeseries.NewPanelBuilder().
+		Title(title).
+		Description(description).
+		Datasource(DatasourceRef).
+		WithTarget(prometheus.NewDataqueryBuilder().
+			Expr(query).
+			LegendFormat(legend).
+			Range(),
+		).
+		Unit(units.Short).
+		LineWidth(1).
+		ShowPoints(common.VisibilityModeNever).
+		Tooltip(common.NewVizTooltipOptionsBuilder().
+			Mode(common.TooltipDisplayModeMulti).
+			Sort(common.SortOrderDescending),
+		).
+		Height(8).
+		Span(12)
+}
+
+// otelcolDroppedLogRecords returns the number of log records per second that
+// were refused by the receivers or failed to be sent or enqueued by the
+// exporters of the collector on each node.
+func otelcolDroppedLogRecords(selector, window string) string {
+	return otelcolSumByNode(
+		fmt.Sprintf(`sum by(instance) (rate(otelcol_receiver_refused_log_records_total{%s}[%s]))`, selector, window),
+		fmt.Sprintf(`sum by(instance) (rate(otelcol_exporter_send_failed_log_records_total{%s}[%s]))`, selector, window),
+		fmt.Sprintf(`sum by(instance) (rate(otelcol_exporter_enqueue_failed_log_records_total{%s}[%s]))`, selector, window),
+	)
+}
+
+// otelcolSumByNode adds up the queries, which are summed by instance. A node
+// missing from some of the queries counts as zero there, as the collector only
+// creates its failure counters on the first failure.
+func otelcolSumByNode(queries ...string) string {
+	all := strings.Join(queries, ` or `)
+	parts := make([]string, len(queries))
+	for i, query := range queries {
+		parts[i] = fmt.Sprintf(`(%s or on(instance) 0 * (%s))`, query, all)
+	}
+	return strings.Join(parts, ` + `)
+}
@@ -1,112 +1,694 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-foundation-sdk/go/cog"
 	"github.com/grafana/grafana-foundation-sdk/go/common"
 	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
 	"github.com/grafana/grafana-foundation-sdk/go/prometheus"
+	"github.com/grafana/grafana-foundation-sdk/go/stat"
+	"github.com/grafana/grafana-foundation-sdk/go/table"
 	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
 	"github.com/grafana/grafana-foundation-sdk/go/units"
 )
 
-var NebiusSharedFilesystem = dashboard.NewDashboardBuilder("Nebius Shared Filesystem").
-	Uid("nebius-shared-filesystem").
-	Description("Dashboard provides an overview of the Nebius Shared Filesystems.").
-	Tags([]string{"Nebius", "NBS"}).
-	Link(dashboard.NewDashboardLinkBuilder("Docs").
-		Type(dashboard.DashboardLinkTypeLink).
-		Url("https://docs.nebius.com/observability").
-		TargetBlank(true).
-		Icon("doc"),
-	).
-	Link(dashboard.NewDashboardLinkBuilder("GitHub").
-		Type(dashboard.DashboardLinkTypeLink).
-		Url("https://github.com/nebius/observability").
-		TargetBlank(true).
-		Icon("external link"),
-	).
-	WithVariable(
-		DatasourceVar,
-	).
-	WithVariable(
-		dashboard.NewQueryVariableBuilder("filestore").
+var NebiusSharedFilesystem = nebiusSharedFilesystem()
+
+func nebiusSharedFilesystem() *dashboard.DashboardBuilder {
+	builder := dashboard.NewDashboardBuilder("Nebius Shared Filesystem").
+		Uid("nebius-shared-filesystem").
+		Description("Dashboard provides an overview of the Nebius Shared Filesystems.").
+		Tags([]string{"Nebius", "NBS"}).
+		Link(dashboard.NewDashboardLinkBuilder("Docs").
+			Type(dashboard.DashboardLinkTypeLink).
+			Url("https://docs.nebius.com/observability").
+			TargetBlank(true).
+			Icon("doc"),
+		).
+		Link(dashboard.NewDashboardLinkBuilder("GitHub").
+			Type(dashboard.DashboardLinkTypeLink).
+			Url("https://github.com/nebius/observability").
+			TargetBlank(true).
+			Icon("external link"),
+		).
+		WithVariable(
+			DatasourceVar,
+		).
+		WithVariable(
+			dashboard.NewQueryVariableBuilder("filestore").
+				Datasource(DatasourceRef).
+				Query(dashboard.StringOrMap{
+					String: New("label_values(filestore)"),
+				}).
+				AllowCustomValue(false),
+		).
+		WithVariable(
+			dashboard.NewQueryVariableBuilder("client").
+				Label("Client").
+				Description("Compute instances using the filesystem.").
+				Datasource(DatasourceRef).
+				Query(dashboard.StringOrMap{
+					String: New(`label_values({__name__=~"filestore_(read|write|index)_ops", filestore="$filestore"}, instance_id)`),
+				}).
+				Multi(true).
+				IncludeAll(true).
+				AllValue(".*").
+				AllowCustomValue(false),
+		).
+		WithVariable(
+			ForecastWindowVar,
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS read latency (quantiles)").
+			Description("Percentiles of the filesystem write requests latency. Measured in milliseconds.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.5, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p50").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.75, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p75").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.90, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p90").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.95, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p95").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p99").
+				Range(),
+			).
+			Unit(units.Milliseconds).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS write latency (quantiles)").
+			Description("Percentiles of the filesystem read requests latency. Measured in milliseconds.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.1, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p10").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.5, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p50").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.75, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p75").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.90, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p90").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.95, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p95").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`histogram_quantile(0.99, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+				LegendFormat("p99").
+				Range(),
+			).
+			Unit(units.Milliseconds).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS read operations").
+			Description("Average read IOPS. Measured in operations per second.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_read_ops{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Read").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(filestore_read_ops_burst{filestore="$filestore"})`).
+				LegendFormat("Read Burst").
+				Range(),
+			).
+			Unit(units.IOOpsPerSecond).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS write operations").
+			Description("Average write IOPS. Measured in operations per second.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_write_ops{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Write").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(filestore_write_ops_burst{filestore="$filestore"})`).
+				LegendFormat("Write Burst").
+				Range(),
+			).
+			Unit(units.IOOpsPerSecond).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS read bytes").
+			Description("Average read throughput. Measured in bytes per second.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_read_bytes{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Read").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(filestore_read_bytes_burst{filestore="$filestore"})`).
+				LegendFormat("Read Burst").
+				Range(),
+			).
+			Unit(units.BytesPerSecondIEC).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS write bytes").
+			Description("Average write throughput. Measured in bytes per second.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_write_bytes{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Write").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(filestore_write_bytes_burst{filestore="$filestore"})`).
+				LegendFormat("Write Burst").
+				Range(),
+			).
+			Unit(units.BytesPerSecondIEC).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS read errors").
+			Description("Number of times a filesystem fails to read data.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_read_errors{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Read").
+				Range(),
+			).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS write errors").
+			Description("Number of times a filesystem fails to write data.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_write_errors{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Write").
+				Range(),
+			).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS index operations").
+			Description("Number of indexing actions (reads, writes, updates and deletions) performed in a time period.").
 			Datasource(DatasourceRef).
-			Query(dashboard.StringOrMap{
-				String: New("label_values(filestore)"),
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_index_ops{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Ops").
+				Range(),
+			).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS index errors").
+			Description("Number of failed indexing operations in a time period.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum(rate(filestore_index_errors{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("Errors").
+				Range(),
+			).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()),
+		).
+		WithRow(dashboard.NewRowBuilder("Error ratio")).
+		WithPanel(stat.NewPanelBuilder().
+			Title("FS availability").
+			Description("Share of successful read, write and index operations within the dashboard time range.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`1 - sum(increase({__name__=~"filestore_(read|write|index)_errors", filestore="$filestore"}[$__range])) / sum(increase({__name__=~"filestore_(read|write|index)_ops", filestore="$filestore"}[$__range]))`).
+				Instant(),
+			).
+			Unit(units.PercentUnit).
+			Decimals(3).
+			Mappings([]dashboard.ValueMapping{
+				{
+					SpecialValueMap: &dashboard.SpecialValueMap{
+						Type: dashboard.MappingTypeSpecialValue,
+						Options: dashboard.DashboardSpecialValueMapOptions{
+							Match: dashboard.SpecialValueMatchNull,
+							Result: dashboard.ValueMappingResult{
+								Text: New("N/A"),
+							},
+						},
+					},
+				},
 			}).
-			AllowCustomValue(false),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS read latency (quantiles)").
-		Description("Percentiles of the filesystem write requests latency. Measured in milliseconds.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.5, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p50").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.75, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p75").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.90, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p90").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.95, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p95").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.99, sum by(le) (rate(filestore_read_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p99").
-			Range(),
-		).
-		Unit(units.Milliseconds).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS write latency (quantiles)").
-		Description("Percentiles of the filesystem read requests latency. Measured in milliseconds.").
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(212, 74, 58)",
+					},
+					{
+						Value: New(0.99),
+						Color: "rgb(237, 129, 40)",
+					},
+					{
+						Value: New(0.999),
+						Color: "rgb(41, 156, 70)",
+					},
+				}),
+			).
+			Height(8).
+			Span(6),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS Read error ratio").
+			Description("Share of read operations that failed.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(filestoreErrorRatio("read", `filestore="$filestore"`, "$__rate_interval")).
+				LegendFormat("Read").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			AxisSoftMin(0).
+			AxisSoftMax(0.01).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(41, 156, 70)",
+					},
+					{
+						Value: New(filestoreErrorRatioThreshold),
+						Color: "rgb(212, 74, 58)",
+					},
+				}),
+			).
+			ThresholdsStyle(common.NewGraphThresholdsStyleConfigBuilder().
+				Mode(common.GraphThresholdsStyleModeDashed),
+			).
+			Height(8).
+			Span(6),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS Write error ratio").
+			Description("Share of write operations that failed.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(filestoreErrorRatio("write", `filestore="$filestore"`, "$__rate_interval")).
+				LegendFormat("Write").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			AxisSoftMin(0).
+			AxisSoftMax(0.01).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(41, 156, 70)",
+					},
+					{
+						Value: New(filestoreErrorRatioThreshold),
+						Color: "rgb(212, 74, 58)",
+					},
+				}),
+			).
+			ThresholdsStyle(common.NewGraphThresholdsStyleConfigBuilder().
+				Mode(common.GraphThresholdsStyleModeDashed),
+			).
+			Height(8).
+			Span(6),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS Index error ratio").
+			Description("Share of index operations that failed.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(filestoreErrorRatio("index", `filestore="$filestore"`, "$__rate_interval")).
+				LegendFormat("Index").
+				Range(),
+			).
+			Unit(units.PercentUnit).
+			AxisSoftMin(0).
+			AxisSoftMax(0.01).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderNone),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder().
+				Steps([]dashboard.Threshold{
+					{
+						Color: "rgb(41, 156, 70)",
+					},
+					{
+						Value: New(filestoreErrorRatioThreshold),
+						Color: "rgb(212, 74, 58)",
+					},
+				}),
+			).
+			ThresholdsStyle(common.NewGraphThresholdsStyleConfigBuilder().
+				Mode(common.GraphThresholdsStyleModeDashed),
+			).
+			Height(8).
+			Span(6),
+		).
+		WithRow(dashboard.NewRowBuilder("Operations by type")).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS index operations by request").
+			Description("Number of index (metadata) operations per second by request type.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (request) (rate(filestore_index_ops{filestore="$filestore"}[$__rate_interval]))`).
+				LegendFormat("{{request}}").
+				Range(),
+			).
+			Unit(units.OpsPerSecond).
+			Stacking(common.NewStackingConfigBuilder().
+				Mode(common.StackingModeNormal),
+			).
+			FillOpacity(20).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderDescending),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(24),
+		).
+		WithRow(dashboard.NewRowBuilder("Clients")).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS operations by client").
+			Description("Number of read, write and index operations per second issued by each compute instance.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate({__name__=~"filestore_(read|write|index)_ops", ` + filestoreClientSelector + `}[$__rate_interval]))`).
+				LegendFormat("{{instance_id}}").
+				Range(),
+			).
+			Unit(units.OpsPerSecond).
+			AxisSoftMin(0).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderDescending),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(12),
+		).
+		WithPanel(timeseries.NewPanelBuilder().
+			Title("FS throughput by client").
+			Description("Bytes read (negative) and written (positive) per second by each compute instance.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate(filestore_write_bytes{`+filestoreClientSelector+`}[$__rate_interval]))`).
+				LegendFormat("{{instance_id}} write").
+				Range(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate(filestore_read_bytes{`+filestoreClientSelector+`}[$__rate_interval]))`).
+				LegendFormat("{{instance_id}} read").
+				Range(),
+			).
+			OverrideByQuery("B", []dashboard.DynamicConfigValue{
+				{
+					Id:    "custom.transform",
+					Value: common.GraphTransformNegativeY,
+				},
+			}).
+			Unit(units.BytesPerSecondIEC).
+			LineWidth(1.5).
+			Tooltip(common.NewVizTooltipOptionsBuilder().
+				Mode(common.TooltipDisplayModeMulti).
+				Sort(common.SortOrderDescending),
+			).
+			Legend(common.NewVizLegendOptionsBuilder().
+				ShowLegend(true),
+			).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(12),
+		).
+		WithPanel(table.NewPanelBuilder().
+			Title("Top clients").
+			Description("Average load of every compute instance on the filesystem within the dashboard time range, sorted by the number of operations.").
+			Datasource(DatasourceRef).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate({__name__=~"filestore_(read|write|index)_ops", `+filestoreClientSelector+`}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Operations").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate(filestore_read_ops{`+filestoreClientSelector+`}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Read ops").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate(filestore_write_ops{`+filestoreClientSelector+`}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Write ops").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate(filestore_index_ops{`+filestoreClientSelector+`}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Index ops").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate(filestore_read_bytes{`+filestoreClientSelector+`}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Read bytes").
+				Instant(),
+			).
+			WithTarget(prometheus.NewDataqueryBuilder().
+				Expr(`sum by (instance_id) (rate(filestore_write_bytes{`+filestoreClientSelector+`}[$__range]))`).
+				Format(prometheus.PromQueryFormatTable).
+				RefId("Write bytes").
+				Instant(),
+			).
+			WithTransformation(dashboard.DataTransformerConfig{
+				Id:      "merge",
+				Options: map[string]any{},
+			}).
+			WithTransformation(organizeFields(
+				[]string{"Time"},
+				map[string]string{
+					"instance_id":        "Client",
+					"Value #Operations":  "Operations",
+					"Value #Read ops":    "Read ops",
+					"Value #Write ops":   "Write ops",
+					"Value #Index ops":   "Index ops",
+					"Value #Read bytes":  "Read bytes",
+					"Value #Write bytes": "Write bytes",
+				},
+			)).
+			OverrideByName("Client", []dashboard.DynamicConfigValue{
+				{Id: "links", Value: []map[string]string{
+					{
+						"title": "Host",
+						"url":   "/d/nebius-compute?var-hostname=${__value.raw}&${__url_time_range}",
+					},
+				}},
+			}).
+			OverrideByName("Operations", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.OpsPerSecond},
+				{Id: "custom.cellOptions", Value: map[string]string{
+					"type": string(common.TableCellDisplayModeGauge),
+					"mode": string(common.BarGaugeDisplayModeBasic),
+				}},
+			}).
+			OverrideByRegexp("Read ops|Write ops|Index ops", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.OpsPerSecond},
+			}).
+			OverrideByRegexp("Read bytes|Write bytes", []dashboard.DynamicConfigValue{
+				{Id: "unit", Value: units.BytesPerSecondIEC},
+			}).
+			SortBy([]cog.Builder[common.TableSortByFieldState]{
+				common.NewTableSortByFieldStateBuilder().
+					DisplayName("Operations").
+					Desc(true),
+			}).
+			Thresholds(dashboard.NewThresholdsConfigBuilder()).
+			Height(8).
+			Span(24),
+		)
+
+	builder.WithRow(dashboard.NewRowBuilder("Metadata operations"))
+	// Three panels a line, the last line shared by the remaining ones.
+	for i, request := range filestoreMetadataRequests {
+		span := uint32(8)
+		if rest := len(filestoreMetadataRequests) % 3; rest != 0 && i >= len(filestoreMetadataRequests)-rest {
+			span = uint32(24 / rest)
+		}
+		builder.WithPanel(filestoreMetadataLatency(request.Title, request.Regexp, span))
+	}
+
+	builder.WithRow(dashboard.NewRowBuilder("Capacity"))
+	for _, panel := range capacityPanels(
+		`sum(`+filestoreUsedBytes+`{filestore="$filestore"})`,
+		`sum(`+filestoreSizeBytes+`{filestore="$filestore"})`,
+		"",
+	) {
+		builder.WithPanel(panel)
+	}
+
+	return builder.
+		Annotation(grafanaAlertsAnnotation()).
+		Annotation(firingAlertsAnnotation(`filestore="$filestore"`)).
+		Time("now-24h", "now").
+		Refresh("1m").
+		Readonly()
+}
+
+// filestoreErrorRatioThreshold is the error ratio above which the filesystem
+// is considered unhealthy on the dashboards and in the alerts.
+const filestoreErrorRatioThreshold = 0.01
+
+// filestoreErrorRatio returns the share of failed operations of the op type
+// ("read", "write" or "index") for each filesystem.
+func filestoreErrorRatio(op, selector, window string) string {
+	return fmt.Sprintf(`sum by (filestore) (rate(filestore_%[1]s_errors{%[2]s}[%[3]s])) / sum by (filestore) (rate(filestore_%[1]s_ops{%[2]s}[%[3]s]))`, op, selector, window)
+}
+
+const filestoreClientSelector = `filestore="$filestore", instance_id=~"$client"`
+
+var filestoreMetadataRequests = []struct {
+	Title  string
+	Regexp string
+}{
+	{"Create", "(?i).*create.*"},
+	{"Unlink", "(?i).*unlink.*"},
+	{"Lookup", "(?i).*lookup.*"},
+	{"List", "(?i).*list.*"},
+	{"Getattr", "(?i).*getattr.*"},
+}
+
+// filestoreMetadataLatency returns the latency quantiles of the index
+// requests matching the request regexp, span columns wide.
+func filestoreMetadataLatency(title, request string, span uint32) *timeseries.PanelBuilder {
+	selector := filestoreClientSelector + `, request=~"` + request + `"`
+	return timeseries.NewPanelBuilder().
+		Title("FS " + strings.ToLower(title) + " latency (quantiles)").
+		Description("Percentiles of the " + strings.ToLower(title) + " metadata requests latency. Measured in milliseconds.").
 		Datasource(DatasourceRef).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.1, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p10").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.5, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+			Expr(`histogram_quantile(0.5, sum by(le) (rate(filestore_index_latency_bucket{` + selector + `}[$__rate_interval])))`).
 			LegendFormat("p50").
 			Range(),
 		).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.75, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p75").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.90, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+			Expr(`histogram_quantile(0.90, sum by(le) (rate(filestore_index_latency_bucket{` + selector + `}[$__rate_interval])))`).
 			LegendFormat("p90").
 			Range(),
 		).
 		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.95, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
-			LegendFormat("p95").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`histogram_quantile(0.99, sum by(le) (rate(filestore_write_latency_bucket{filestore="$filestore"}[$__rate_interval])))`).
+			Expr(`histogram_quantile(0.99, sum by(le) (rate(filestore_index_latency_bucket{` + selector + `}[$__rate_interval])))`).
 			LegendFormat("p99").
 			Range(),
 		).
@@ -120,164 +702,7 @@ var NebiusSharedFilesystem = dashboard.NewDashboardBuilder("Nebius Shared Filesy
 		Legend(common.NewVizLegendOptionsBuilder().
 			ShowLegend(true),
 		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS read operations").
-		Description("Average read IOPS. Measured in operations per second.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_read_ops{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Read").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(filestore_read_ops_burst{filestore="$filestore"})`).
-			LegendFormat("Read Burst").
-			Range(),
-		).
-		Unit(units.IOOpsPerSecond).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS write operations").
-		Description("Average write IOPS. Measured in operations per second.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_write_ops{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Write").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(filestore_write_ops_burst{filestore="$filestore"})`).
-			LegendFormat("Write Burst").
-			Range(),
-		).
-		Unit(units.IOOpsPerSecond).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS read bytes").
-		Description("Average read throughput. Measured in bytes per second.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_read_bytes{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Read").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(filestore_read_bytes_burst{filestore="$filestore"})`).
-			LegendFormat("Read Burst").
-			Range(),
-		).
-		Unit(units.BytesPerSecondIEC).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS write bytes").
-		Description("Average write throughput. Measured in bytes per second.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_write_bytes{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Write").
-			Range(),
-		).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(filestore_write_bytes_burst{filestore="$filestore"})`).
-			LegendFormat("Write Burst").
-			Range(),
-		).
-		Unit(units.BytesPerSecondIEC).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Tooltip(common.NewVizTooltipOptionsBuilder().
-			Mode(common.TooltipDisplayModeMulti).
-			Sort(common.SortOrderNone),
-		).
-		Legend(common.NewVizLegendOptionsBuilder().
-			ShowLegend(true),
-		).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS read errors").
-		Description("Number of times a filesystem fails to read data.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_read_errors{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Read").
-			Range(),
-		).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS write errors").
-		Description("Number of times a filesystem fails to write data.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_write_errors{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Write").
-			Range(),
-		).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS index operations").
-		Description("Number of indexing actions (reads, writes, updates and deletions) performed in a time period.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_index_ops{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Ops").
-			Range(),
-		).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	WithPanel(timeseries.NewPanelBuilder().
-		Title("FS index errors").
-		Description("Number of failed indexing operations in a time period.").
-		Datasource(DatasourceRef).
-		WithTarget(prometheus.NewDataqueryBuilder().
-			Expr(`sum(rate(filestore_index_errors{filestore="$filestore"}[$__rate_interval]))`).
-			LegendFormat("Errors").
-			Range(),
-		).
-		AxisSoftMin(0).
-		LineWidth(1.5).
-		Thresholds(dashboard.NewThresholdsConfigBuilder()),
-	).
-	Time("now-24h", "now").
-	Refresh("1m").
-	Readonly()
+		Thresholds(dashboard.NewThresholdsConfigBuilder()).
+		Height(8).
+		Span(span)
+}
@@ -1,5 +1,51 @@
 package main
 
+import (
+	"strconv"
+
+	"github.com/grafana/grafana-foundation-sdk/go/dashboard"
+)
+
 func New[T any](v T) *T {
 	return &v
 }
+
+func organizeFields(exclude []string, rename map[string]string) dashboard.DataTransformerConfig {
+	excludeByName := map[string]bool{}
+	for _, name := range exclude {
+		excludeByName[name] = true
+	}
+	return dashboard.DataTransformerConfig{
+		Id: "organize",
+		Options: map[string]any{
+			"excludeByName": excludeByName,
+			"renameByName":  rename,
+		},
+	}
+}
+
+// maxFromQuery sets the max of the fields of all other queries to the value
+// returned by the query with refId, so that gauges and percentage thresholds
+// follow a limit reported by a metric. The query legend must be refId as well.
+func maxFromQuery(refId string) dashboard.DataTransformerConfig {
+	return dashboard.DataTransformerConfig{
+		Id: "configFromData",
+		Options: map[string]any{
+			"configRefId": refId,
+			"mappings": []map[string]string{
+				{"fieldName": refId, "handlerKey": "max"},
+			},
+		},
+	}
+}
+
+// priceVariable returns a textbox variable holding a price, which defaults to
+// the given list price and can be overridden with the price of a contract.
+func priceVariable(name, label, description string, price float64) *dashboard.TextBoxVariableBuilder {
+	return dashboard.NewTextBoxVariableBuilder(name).
+		Label(label).
+		Description(description).
+		DefaultValue(dashboard.StringOrMap{
+			String: New(strconv.FormatFloat(price, 'f', -1, 64)),
+		})
+}